@@ -0,0 +1,456 @@
+package dbf
+
+import (
+	"fmt"
+	"io"
+	"time"
+
+	"golang.org/x/text/encoding"
+)
+
+// An Editor modifies records of an existing DBF file in place.
+// A record is read by GoTo, modified by the setters
+// and written back by Update.
+type Editor struct {
+	header  *header
+	fields  *Fields
+	rws     io.ReadWriteSeeker
+	buf     []byte
+	recNo   uint32
+	decoder *encoding.Decoder
+	encoder *encoding.Encoder
+	memo    *memoWriter
+	memoR   *memoReader
+	version Version
+	err     error
+}
+
+// NewEditor returns a new Editor that modifies records of the DBF file rws.
+// The header and the fields are read from the file.
+func NewEditor(rws io.ReadWriteSeeker) (*Editor, error) {
+	if rws == nil {
+		return nil, fmt.Errorf("dbf.NewEditor: parameter is nil")
+	}
+	e, err := newEditor(rws)
+	if err != nil {
+		return nil, fmt.Errorf("dbf.NewEditor: %w", err)
+	}
+	return e, nil
+}
+
+func newEditor(rws io.ReadWriteSeeker) (*Editor, error) {
+	if _, err := rws.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	r, err := newReader(rws)
+	if err != nil {
+		return nil, err
+	}
+	e := &Editor{
+		header:  r.header,
+		fields:  r.fields,
+		rws:     rws,
+		buf:     r.buf,
+		decoder: r.decoder,
+		version: r.version,
+	}
+	if cp := e.header.codePage(); cp != 0 {
+		e.encoder = charmapByPage(cp).NewEncoder()
+	}
+	return e, nil
+}
+
+// Err returns the first error that was encountered by the Editor.
+func (e *Editor) Err() error {
+	if e.err != nil {
+		return fmt.Errorf("dbf.Editor: %w", e.err)
+	}
+	return nil
+}
+
+// SetMemoAppender sets the existing memo file used to write memo fields.
+// The memo data are written after the last used block of the file.
+// If rws implements io.ReaderAt, e.g. *os.File, the memo fields
+// can also be read.
+func (e *Editor) SetMemoAppender(rws io.ReadWriteSeeker) {
+	if e.err != nil {
+		return
+	}
+	if rws == nil {
+		e.err = fmt.Errorf("SetMemoAppender: parameter is nil")
+		return
+	}
+	memo, err := openMemoWriter(rws, e.version)
+	if err != nil {
+		e.err = fmt.Errorf("SetMemoAppender: %w", err)
+		return
+	}
+	if ra, ok := rws.(io.ReaderAt); ok {
+		e.memoR, err = newMemoReader(ra, e.version)
+		if err != nil {
+			e.err = fmt.Errorf("SetMemoAppender: %w", err)
+			return
+		}
+	}
+	e.memo = memo
+}
+
+// memoReader returns the reader of the memo file
+// with the data written since the last Update.
+func (e *Editor) memoReader() (*memoReader, error) {
+	if e.memo != nil {
+		if err := e.memo.writer.Flush(); err != nil {
+			return nil, err
+		}
+	}
+	return e.memoR, nil
+}
+
+// RecordCount returns the number of records in the DBF file.
+func (e *Editor) RecordCount() uint32 {
+	if e.err != nil {
+		return 0
+	}
+	return e.header.RecCount
+}
+
+// Fields returns the file structure.
+func (e *Editor) Fields() *Fields {
+	if e.err != nil {
+		return nil
+	}
+	return e.fields
+}
+
+// GoTo reads the record by number. The first record number is 1.
+// Returns false if the record does not exist or an error occurs.
+func (e *Editor) GoTo(recNo uint32) bool {
+	if e.err != nil {
+		return false
+	}
+	if recNo == 0 || recNo > e.header.RecCount {
+		return false
+	}
+	if _, err := e.rws.Seek(e.offset(recNo), io.SeekStart); err != nil {
+		e.err = fmt.Errorf("GoTo: %w", &RecordError{RecNo: recNo, Err: err})
+		return false
+	}
+	if _, err := io.ReadFull(e.rws, e.buf); err != nil {
+		e.err = fmt.Errorf("GoTo: %w", &RecordError{RecNo: recNo, Err: err})
+		return false
+	}
+	e.recNo = recNo
+	return true
+}
+
+func (e *Editor) offset(recNo uint32) int64 {
+	return int64(e.header.DataOffset) + int64(recNo-1)*int64(e.header.RecSize)
+}
+
+// RecNo returns the number of the current record.
+// Returns 0 if no record is read.
+func (e *Editor) RecNo() uint32 {
+	if e.err != nil {
+		return 0
+	}
+	return e.recNo
+}
+
+// Update writes the current record back to the file
+// and sets the modified date in the file header.
+func (e *Editor) Update() {
+	if e.err != nil {
+		return
+	}
+	if e.recNo == 0 {
+		e.err = fmt.Errorf("Update: no current record, use GoTo")
+		return
+	}
+	if err := e.update(); err != nil {
+		e.err = fmt.Errorf("Update: %w", &RecordError{RecNo: e.recNo, Err: err})
+	}
+}
+
+func (e *Editor) update() error {
+	if e.memo != nil {
+		if err := e.memo.flush(); err != nil {
+			return err
+		}
+	}
+	if _, err := e.rws.Seek(e.offset(e.recNo), io.SeekStart); err != nil {
+		return err
+	}
+	if _, err := e.rws.Write(e.buf); err != nil {
+		return err
+	}
+	// modify date in header
+	if _, err := e.rws.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	e.header.setModDate(time.Now())
+	return e.header.write(e.rws)
+}
+
+// valueError returns err with the record and field context.
+func (e *Editor) valueError(fn string, index int, err error) error {
+	return fmt.Errorf("%s: %w", fn, &RecordError{RecNo: e.recNo, Err: e.fields.fieldError(index, err)})
+}
+
+// Deleted returns deleted record flag.
+func (e *Editor) Deleted() bool {
+	if e.err != nil {
+		return false
+	}
+	return e.buf[0] == '*'
+}
+
+// SetDeleted sets or clears the deleted flag of the current record.
+func (e *Editor) SetDeleted(deleted bool) {
+	if e.err != nil {
+		return
+	}
+	if deleted {
+		e.buf[0] = '*'
+	} else {
+		e.buf[0] = ' '
+	}
+}
+
+// StringFieldValue returns the value of the field by index.
+// See Reader.StringFieldValue.
+// For a memo field the memo file must be set by SetMemoAppender.
+func (e *Editor) StringFieldValue(index int) string {
+	if e.err != nil {
+		return ""
+	}
+	memo, err := e.memoReader()
+	if err != nil {
+		e.err = e.valueError("StringFieldValue", index, err)
+		return ""
+	}
+	value, err := e.fields.stringFieldValue(index, e.buf, e.decoder, memo)
+	if err != nil {
+		e.err = e.valueError("StringFieldValue", index, err)
+	}
+	return value
+}
+
+// BytesFieldValue returns the value of the field by index.
+// See Reader.BytesFieldValue.
+// For a memo type field the memo file must be set by SetMemoAppender.
+func (e *Editor) BytesFieldValue(index int) []byte {
+	if e.err != nil {
+		return nil
+	}
+	memo, err := e.memoReader()
+	if err != nil {
+		e.err = e.valueError("BytesFieldValue", index, err)
+		return nil
+	}
+	value, err := e.fields.bytesFieldValue(index, e.buf, memo)
+	if err != nil {
+		e.err = e.valueError("BytesFieldValue", index, err)
+	}
+	return value
+}
+
+// BoolFieldValue returns the value of the field by index.
+// Field type must be Logical.
+func (e *Editor) BoolFieldValue(index int) bool {
+	if e.err != nil {
+		return false
+	}
+	value, err := e.fields.boolFieldValue(index, e.buf)
+	if err != nil {
+		e.err = e.valueError("BoolFieldValue", index, err)
+	}
+	return value
+}
+
+// DateFieldValue returns the value of the field by index.
+// Field type must be Date.
+func (e *Editor) DateFieldValue(index int) time.Time {
+	if e.err != nil {
+		return time.Time{}
+	}
+	value, err := e.fields.dateFieldValue(index, e.buf)
+	if err != nil {
+		e.err = e.valueError("DateFieldValue", index, err)
+	}
+	return value
+}
+
+// IntFieldValue returns the value of the field by index.
+// See Reader.IntFieldValue.
+func (e *Editor) IntFieldValue(index int) int64 {
+	if e.err != nil {
+		return 0
+	}
+	value, err := e.fields.intFieldValue(index, e.buf)
+	if err != nil {
+		e.err = e.valueError("IntFieldValue", index, err)
+	}
+	return value
+}
+
+// FloatFieldValue returns the value of the field by index.
+// See Reader.FloatFieldValue.
+func (e *Editor) FloatFieldValue(index int) float64 {
+	if e.err != nil {
+		return 0
+	}
+	value, err := e.fields.floatFieldValue(index, e.buf)
+	if err != nil {
+		e.err = e.valueError("FloatFieldValue", index, err)
+	}
+	return value
+}
+
+// CurrencyFieldValue returns the value of the field by index.
+// See Reader.CurrencyFieldValue.
+func (e *Editor) CurrencyFieldValue(index int) int64 {
+	if e.err != nil {
+		return 0
+	}
+	value, err := e.fields.currencyFieldValue(index, e.buf)
+	if err != nil {
+		e.err = e.valueError("CurrencyFieldValue", index, err)
+	}
+	return value
+}
+
+// DateTimeFieldValue returns the value of the field by index.
+// See Reader.DateTimeFieldValue.
+func (e *Editor) DateTimeFieldValue(index int) time.Time {
+	if e.err != nil {
+		return time.Time{}
+	}
+	value, err := e.fields.dateTimeFieldValue(index, e.buf)
+	if err != nil {
+		e.err = e.valueError("DateTimeFieldValue", index, err)
+	}
+	return value
+}
+
+// IsNull reports whether the value of the field by index is null.
+// See Reader.IsNull.
+func (e *Editor) IsNull(index int) bool {
+	if e.err != nil {
+		return false
+	}
+	value, err := e.fields.isNull(index, e.buf)
+	if err != nil {
+		e.err = e.valueError("IsNull", index, err)
+	}
+	return value
+}
+
+// SetStringFieldValue assigns a value to a field by index.
+// See Writer.SetStringFieldValue.
+// For a memo field the memo file must be set by SetMemoAppender.
+func (e *Editor) SetStringFieldValue(index int, value string) {
+	if e.err != nil {
+		return
+	}
+	err := e.fields.setStringFieldValue(index, e.buf, value, e.encoder, e.memo)
+	if err != nil {
+		e.err = e.valueError("SetStringFieldValue", index, err)
+	}
+}
+
+// SetBytesFieldValue assigns a value to a field by index.
+// See Writer.SetBytesFieldValue.
+// For a memo type field the memo file must be set by SetMemoAppender.
+func (e *Editor) SetBytesFieldValue(index int, value []byte) {
+	if e.err != nil {
+		return
+	}
+	err := e.fields.setBytesFieldValue(index, e.buf, value, e.memo)
+	if err != nil {
+		e.err = e.valueError("SetBytesFieldValue", index, err)
+	}
+}
+
+// SetBoolFieldValue assigns a value to a field by index.
+// Field type must be Logical.
+func (e *Editor) SetBoolFieldValue(index int, value bool) {
+	if e.err != nil {
+		return
+	}
+	err := e.fields.setBoolFieldValue(index, e.buf, value)
+	if err != nil {
+		e.err = e.valueError("SetBoolFieldValue", index, err)
+	}
+}
+
+// SetDateFieldValue assigns a value to a field by index.
+// Field type must be Date.
+func (e *Editor) SetDateFieldValue(index int, value time.Time) {
+	if e.err != nil {
+		return
+	}
+	err := e.fields.setDateFieldValue(index, e.buf, value)
+	if err != nil {
+		e.err = e.valueError("SetDateFieldValue", index, err)
+	}
+}
+
+// SetIntFieldValue assigns a value to a field by index.
+// Field type must be Numeric, Float or Integer.
+func (e *Editor) SetIntFieldValue(index int, value int64) {
+	if e.err != nil {
+		return
+	}
+	err := e.fields.setIntFieldValue(index, e.buf, value)
+	if err != nil {
+		e.err = e.valueError("SetIntFieldValue", index, err)
+	}
+}
+
+// SetFloatFieldValue assigns a value to a field by index.
+// See Writer.SetFloatFieldValue.
+func (e *Editor) SetFloatFieldValue(index int, value float64) {
+	if e.err != nil {
+		return
+	}
+	err := e.fields.setFloatFieldValue(index, e.buf, value)
+	if err != nil {
+		e.err = e.valueError("SetFloatFieldValue", index, err)
+	}
+}
+
+// SetCurrencyFieldValue assigns a value to a field by index.
+// See Writer.SetCurrencyFieldValue.
+func (e *Editor) SetCurrencyFieldValue(index int, value int64) {
+	if e.err != nil {
+		return
+	}
+	err := e.fields.setCurrencyFieldValue(index, e.buf, value)
+	if err != nil {
+		e.err = e.valueError("SetCurrencyFieldValue", index, err)
+	}
+}
+
+// SetDateTimeFieldValue assigns a value to a field by index.
+// See Writer.SetDateTimeFieldValue.
+func (e *Editor) SetDateTimeFieldValue(index int, value time.Time) {
+	if e.err != nil {
+		return
+	}
+	err := e.fields.setDateTimeFieldValue(index, e.buf, value)
+	if err != nil {
+		e.err = e.valueError("SetDateTimeFieldValue", index, err)
+	}
+}
+
+// SetNull sets the value of the field by index to null.
+// See Writer.SetNull.
+func (e *Editor) SetNull(index int) {
+	if e.err != nil {
+		return
+	}
+	err := e.fields.setNull(index, e.buf)
+	if err != nil {
+		e.err = e.valueError("SetNull", index, err)
+	}
+}
@@ -0,0 +1,214 @@
+package dbf
+
+import (
+	"io"
+	"testing"
+	"time"
+)
+
+func Test_Editor(t *testing.T) {
+	fields := NewFields()
+	fields.AddCharacterField("NAME", 10)
+	fields.AddNumericField("COUNT", 5, 0)
+	fields.AddMemoField("NOTE")
+
+	dbf := &memFile{}
+	memo := &memFile{}
+	w, err := NewWriterOptions(dbf, fields, WriterOptions{CodePage: 866, Version: FoxPro})
+	if err != nil {
+		t.Fatalf("NewWriterOptions(): %v", err)
+	}
+	w.SetMemoWriter(memo)
+	for i, name := range []string{"Мышь", "Кот", "Пёс"} {
+		w.SetStringFieldValue(0, name)
+		w.SetIntFieldValue(1, int64(i+1))
+		w.SetStringFieldValue(2, name)
+		w.Write()
+	}
+	w.Flush()
+	if w.Err() != nil {
+		t.Fatalf("Writer: %v", w.Err())
+	}
+	// Set old modified date
+	dbf.buf[1], dbf.buf[2], dbf.buf[3] = 90, 1, 1
+
+	e, err := NewEditor(dbf)
+	if err != nil {
+		t.Fatalf("NewEditor(): %v", err)
+	}
+	e.SetMemoAppender(memo)
+	if e.GoTo(0) || e.GoTo(4) {
+		t.Errorf("e.GoTo(): nonexistent record: want: false")
+	}
+	if !e.GoTo(2) {
+		t.Fatalf("e.GoTo(2): want: true")
+	}
+	if got := e.StringFieldValue(0); got != "Кот" {
+		t.Errorf("e.StringFieldValue(0): want: %v, got: %v", "Кот", got)
+	}
+	if got := e.StringFieldValue(2); got != "Кот" {
+		t.Errorf("e.StringFieldValue(2): memo: want: %v, got: %v", "Кот", got)
+	}
+	e.SetDeleted(true)
+	e.SetStringFieldValue(0, "Кошка")
+	e.SetStringFieldValue(2, "Кошка")
+	if got := e.StringFieldValue(2); got != "Кошка" {
+		t.Errorf("e.StringFieldValue(2): memo before Update: want: %v, got: %v", "Кошка", got)
+	}
+	e.Update()
+	if !e.GoTo(3) {
+		t.Fatalf("e.GoTo(3): want: true")
+	}
+	e.SetNull(1)
+	e.Update()
+	if e.Err() != nil {
+		t.Fatalf("Editor: %v", e.Err())
+	}
+
+	dbf.Seek(0, io.SeekStart)
+	r, err := NewReader(dbf)
+	if err != nil {
+		t.Fatalf("NewReader(): %v", err)
+	}
+	r.SetMemoReader(memo)
+	today := time.Now()
+	if d := r.ModDate(); d.Year() != today.Year() || d.YearDay() != today.YearDay() {
+		t.Errorf("r.ModDate(): want: %v, got: %v", today.Format("2006-01-02"), d.Format("2006-01-02"))
+	}
+	if r.RecordCount() != 3 {
+		t.Errorf("r.RecordCount(): want: %v, got: %v", 3, r.RecordCount())
+	}
+	tests := []struct {
+		name    string
+		note    string
+		count   int64
+		deleted bool
+	}{
+		{"Мышь", "Мышь", 1, false},
+		{"Кошка", "Кошка", 2, true},
+		{"Пёс", "Пёс", 0, false},
+	}
+	for i, tc := range tests {
+		if !r.Read() {
+			t.Fatalf("r.Read(): record %d: want: true", i+1)
+		}
+		if r.StringFieldValue(0) != tc.name || r.StringFieldValue(2) != tc.note || r.IntFieldValue(1) != tc.count || r.Deleted() != tc.deleted {
+			t.Errorf("record %d: want: %v %v %v %v, got: %v %v %v %v", i+1, tc.name, tc.note, tc.count, tc.deleted,
+				r.StringFieldValue(0), r.StringFieldValue(2), r.IntFieldValue(1), r.Deleted())
+		}
+	}
+	if r.Read() {
+		t.Errorf("r.Read(): want: false")
+	}
+	if r.Err() != nil {
+		t.Errorf("Reader: %v", r.Err())
+	}
+
+	// Recall
+	e.GoTo(2)
+	e.SetDeleted(false)
+	e.Update()
+	e.GoTo(2)
+	if e.Deleted() {
+		t.Errorf("e.Deleted(): want: false")
+	}
+}
+
+func Test_Editor_invalid(t *testing.T) {
+	if _, err := NewEditor(nil); err == nil {
+		t.Errorf("NewEditor(nil): error required")
+	}
+	e, err := NewEditor(testProductFile(t))
+	if err != nil {
+		t.Fatalf("NewEditor(): %v", err)
+	}
+	e.Update()
+	if e.Err() == nil {
+		t.Errorf("e.Update(): no current record: error required")
+	}
+}
+
+func Test_Editor_reserved_field_bytes(t *testing.T) {
+	f := testProductFile(t)
+	// Garbage in the reserved bytes of the first dBase III field descriptor
+	f.buf[headerSize+18] = byte(FlagNullable)
+
+	e, err := NewEditor(f)
+	if err != nil {
+		t.Fatalf("NewEditor(): %v", err)
+	}
+	e.GoTo(1)
+	e.IsNull(0)
+	e.SetStringFieldValue(0, "Cat")
+	e.Update()
+	if e.Err() != nil {
+		t.Errorf("Editor: %v", e.Err())
+	}
+}
+
+func Test_Editor_field_values(t *testing.T) {
+	e, err := NewEditor(testProductFile(t))
+	if err != nil {
+		t.Fatalf("NewEditor(): %v", err)
+	}
+	if !e.GoTo(1) {
+		t.Fatalf("e.GoTo(1): want: true")
+	}
+	date := time.Date(2021, 2, 12, 0, 0, 0, 0, time.UTC)
+	if got := e.IntFieldValue(1); got != 12 {
+		t.Errorf("e.IntFieldValue(1): want: %v, got: %v", 12, got)
+	}
+	if got := e.FloatFieldValue(2); got != 1.25 {
+		t.Errorf("e.FloatFieldValue(2): want: %v, got: %v", 1.25, got)
+	}
+	if got := e.BoolFieldValue(3); !got {
+		t.Errorf("e.BoolFieldValue(3): want: %v, got: %v", true, got)
+	}
+	if got := e.DateFieldValue(4); !got.Equal(date) {
+		t.Errorf("e.DateFieldValue(4): want: %v, got: %v", date, got)
+	}
+	if e.Err() != nil {
+		t.Fatalf("Editor: %v", e.Err())
+	}
+	e.BytesFieldValue(0)
+	if e.Err() == nil {
+		t.Errorf("e.BytesFieldValue(0): character field: error required")
+	}
+
+	fields := NewFields()
+	fields.AddCurrencyField("PRICE")
+	fields.AddDateTimeField("TIME")
+	fields.AddVarbinaryField("DATA", 5)
+	f := &memFile{}
+	w, err := NewWriterOptions(f, fields, WriterOptions{Version: VisualFoxPro})
+	if err != nil {
+		t.Fatalf("NewWriterOptions(): %v", err)
+	}
+	tm := time.Date(2021, 2, 12, 13, 45, 30, 0, time.UTC)
+	w.SetCurrencyFieldValue(0, 125000)
+	w.SetDateTimeFieldValue(1, tm)
+	w.SetBytesFieldValue(2, []byte{1, 2})
+	w.Write()
+	w.Flush()
+	if w.Err() != nil {
+		t.Fatalf("Writer: %v", w.Err())
+	}
+
+	e, err = NewEditor(f)
+	if err != nil {
+		t.Fatalf("NewEditor(): %v", err)
+	}
+	e.GoTo(1)
+	if got := e.CurrencyFieldValue(0); got != 125000 {
+		t.Errorf("e.CurrencyFieldValue(0): want: %v, got: %v", 125000, got)
+	}
+	if got := e.DateTimeFieldValue(1); !got.Equal(tm) {
+		t.Errorf("e.DateTimeFieldValue(1): want: %v, got: %v", tm, got)
+	}
+	if got := e.BytesFieldValue(2); len(got) != 2 || got[0] != 1 || got[1] != 2 {
+		t.Errorf("e.BytesFieldValue(2): want: %v, got: %v", []byte{1, 2}, got)
+	}
+	if e.Err() != nil {
+		t.Errorf("Editor: %v", e.Err())
+	}
+}
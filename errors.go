@@ -0,0 +1,62 @@
+package dbf
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrTruncated is returned when the file data is shorter
+	// than declared in the header.
+	ErrTruncated = errors.New("truncated data")
+
+	// ErrRecordCount is reported when the number of records in the file
+	// differs from the record count in the header.
+	ErrRecordCount = errors.New("record count mismatch")
+
+	// ErrFieldOverflow is returned when a value does not fit the field
+	// or the field value does not fit the Go type.
+	ErrFieldOverflow = errors.New("overflow")
+
+	// ErrTypeMismatch is returned when the field type does not match
+	// the type of the value.
+	ErrTypeMismatch = errors.New("type mismatch")
+
+	// ErrNotDBF is returned when the file header is not a DBF file header.
+	ErrNotDBF = errors.New("not DBF file")
+
+	// ErrUnsupportedCodePage is returned for a code page
+	// which is not supported.
+	ErrUnsupportedCodePage = errors.New("unsupported code page")
+)
+
+// A FieldError records an error with the value of a field.
+type FieldError struct {
+	Index int    // field index
+	Name  string // field name
+	Type  byte   // field type
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("field %q: %v", e.Name, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
+
+// A RecordError records an error with a record.
+// The first record number is 1.
+type RecordError struct {
+	RecNo uint32
+	Err   error
+}
+
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("record %d: %v", e.RecNo, e.Err)
+}
+
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
@@ -0,0 +1,124 @@
+package dbf
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func Test_ErrNotDBF(t *testing.T) {
+	buf := make([]byte, 64)
+	_, err := NewReader(bytes.NewReader(buf))
+	if !errors.Is(err, ErrNotDBF) {
+		t.Errorf("NewReader(): want: %v, got: %v", ErrNotDBF, err)
+	}
+}
+
+func Test_ErrUnsupportedCodePage(t *testing.T) {
+	fields := NewFields()
+	fields.AddCharacterField("NAME", 10)
+	_, err := NewWriter(&memFile{}, fields, 1)
+	if !errors.Is(err, ErrUnsupportedCodePage) {
+		t.Errorf("NewWriter(): want: %v, got: %v", ErrUnsupportedCodePage, err)
+	}
+
+	r, err := NewReader(testProductFile(t))
+	if err != nil {
+		t.Fatalf("NewReader(): %v", err)
+	}
+	r.SetCodePage(1)
+	if !errors.Is(r.Err(), ErrUnsupportedCodePage) {
+		t.Errorf("r.SetCodePage(): want: %v, got: %v", ErrUnsupportedCodePage, r.Err())
+	}
+}
+
+func Test_Writer_FieldError(t *testing.T) {
+	tests := []struct {
+		name  string
+		set   func(w *Writer)
+		index int
+		err   error
+	}{
+		{
+			name:  "overflow",
+			set:   func(w *Writer) { w.SetStringFieldValue(1, "abcdef") },
+			index: 1,
+			err:   ErrFieldOverflow,
+		},
+		{
+			name:  "numeric overflow",
+			set:   func(w *Writer) { w.SetIntFieldValue(2, 123456) },
+			index: 2,
+			err:   ErrFieldOverflow,
+		},
+		{
+			name:  "type mismatch",
+			set:   func(w *Writer) { w.SetBoolFieldValue(1, true) },
+			index: 1,
+			err:   ErrTypeMismatch,
+		},
+	}
+	for _, tc := range tests {
+		fields := NewFields()
+		fields.AddCharacterField("NAME", 10)
+		fields.AddCharacterField("CODE", 5)
+		fields.AddNumericField("COUNT", 5, 0)
+		w, err := NewWriter(&memFile{}, fields, 0)
+		if err != nil {
+			t.Fatalf("NewWriter(): %v", err)
+		}
+		w.Write()
+		tc.set(w)
+
+		err = w.Err()
+		if !errors.Is(err, tc.err) {
+			t.Errorf("%s: w.Err(): want: %v, got: %v", tc.name, tc.err, err)
+		}
+		var fe *FieldError
+		if !errors.As(err, &fe) {
+			t.Fatalf("%s: w.Err(): FieldError required: %v", tc.name, err)
+		}
+		name, typ, _, _ := fields.FieldInfo(tc.index)
+		if fe.Index != tc.index || fe.Name != name || string(fe.Type) != typ {
+			t.Errorf("%s: FieldError: want: %v %v %v, got: %v %v %c", tc.name, tc.index, name, typ, fe.Index, fe.Name, fe.Type)
+		}
+		var re *RecordError
+		if !errors.As(err, &re) || re.RecNo != 2 {
+			t.Errorf("%s: w.Err(): RecordError with record 2 required: %v", tc.name, err)
+		}
+	}
+}
+
+func Test_Reader_RecordError(t *testing.T) {
+	r, err := NewReader(testProductFile(t))
+	if err != nil {
+		t.Fatalf("NewReader(): %v", err)
+	}
+	r.Read()
+	r.Read()
+	var v struct {
+		Count uint `dbf:"COUNT"`
+	}
+	err = r.Decode(&v)
+	if !errors.Is(err, ErrFieldOverflow) {
+		t.Errorf("Decode(): want: %v, got: %v", ErrFieldOverflow, err)
+	}
+	var re *RecordError
+	if !errors.As(err, &re) || re.RecNo != 2 {
+		t.Errorf("Decode(): RecordError with record 2 required: %v", err)
+	}
+	var fe *FieldError
+	if !errors.As(err, &fe) || fe.Name != "COUNT" || fe.Index != 1 {
+		t.Errorf("Decode(): FieldError with field COUNT required: %v", err)
+	}
+
+	r, err = NewReader(testProductFile(t))
+	if err != nil {
+		t.Fatalf("NewReader(): %v", err)
+	}
+	r.Read()
+	r.DateFieldValue(0)
+	if !errors.Is(r.Err(), ErrTypeMismatch) {
+		t.Errorf("r.DateFieldValue(): want: %v, got: %v", ErrTypeMismatch, r.Err())
+	}
+}
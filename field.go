@@ -5,6 +5,7 @@ import (
 	"encoding/binary"
 	"fmt"
 	"io"
+	"math"
 	"strconv"
 	"strings"
 	"time"
@@ -18,17 +19,87 @@ const (
 	maxNameLen      = 10
 	maxCharacterLen = 254
 	maxNumericLen   = 19
+	maxFloatLen     = 20
+	memoLen         = 10
+	integerLen      = 4
+	maxAutoIncStep  = 255
+	currencyLen     = 8
+	currencyDec     = 4
+
+	currencyScale = 10000
+
+	dateTimeLen = 8
+
+	doubleLen    = 8
+	maxDoubleDec = 18
+
+	// Julian day number of 1970-01-01
+	julianDayUnixEpoch = 2440588
+	secondsPerDay      = 24 * 60 * 60
+
+	visualFoxProMemoLen = 4
+
+	// dBase 7 field descriptor
+	level7FieldSize  = 48
+	maxLevel7NameLen = 31
 )
 
 type field struct {
-	Name   [11]byte
+	Name   [32]byte
 	Type   byte
 	Offset uint32
 	Len    byte
 	Dec    byte
-	Filler [14]byte
+	Flags  byte
+	// Autoincrement next value and step
+	AutoIncNext uint32
+	AutoIncStep byte
+	// Field of dBase 7 file
+	level7 bool
+}
+
+// fieldDescriptor is the field descriptor of dBase III - Visual FoxPro files.
+type fieldDescriptor struct {
+	Name        [11]byte
+	Type        byte
+	Offset      uint32
+	Len         byte
+	Dec         byte
+	Flags       byte
+	AutoIncNext uint32
+	AutoIncStep byte
+	Filler      [8]byte
 }
 
+// level7FieldDescriptor is the field descriptor of dBase 7 files.
+type level7FieldDescriptor struct {
+	Name        [32]byte
+	Type        byte
+	Len         byte
+	Dec         byte
+	Filler1     [2]byte
+	MDX         byte
+	Filler2     [2]byte
+	AutoIncNext uint32
+	Filler3     [4]byte
+}
+
+// FieldFlags are the Visual FoxPro field flags.
+type FieldFlags byte
+
+// Field flags.
+const (
+	// FlagSystem is a system field, not visible to user.
+	FlagSystem FieldFlags = 0x01
+	// FlagNullable is a field that can store null values.
+	FlagNullable FieldFlags = 0x02
+	// FlagBinary is a Character or Memo field which value is not
+	// translated by the code page.
+	FlagBinary FieldFlags = 0x04
+	// FlagAutoIncrement is an autoincrementing field.
+	FlagAutoIncrement FieldFlags = 0x0C
+)
+
 // New field
 
 func newLogicalField(name string) (*field, error) {
@@ -68,11 +139,19 @@ func newCharacterField(name string, length int) (*field, error) {
 }
 
 func newNumericField(name string, length, dec int) (*field, error) {
+	return newNumberField(name, 'N', length, dec, maxNumericLen)
+}
+
+func newFloatField(name string, length, dec int) (*field, error) {
+	return newNumberField(name, 'F', length, dec, maxFloatLen)
+}
+
+func newNumberField(name string, typ byte, length, dec, maxLen int) (*field, error) {
 	if err := checkName(name); err != nil {
 		return nil, err
 	}
-	if length <= 0 || length > maxNumericLen {
-		return nil, fmt.Errorf("field len %d, want 0 < len <= %d", length, maxNumericLen)
+	if length <= 0 || length > maxLen {
+		return nil, fmt.Errorf("field len %d, want 0 < len <= %d", length, maxLen)
 	}
 	if dec < 0 {
 		return nil, fmt.Errorf("field dec %d, want dec > 0", dec)
@@ -85,12 +164,114 @@ func newNumericField(name string, length, dec int) (*field, error) {
 	}
 	f := &field{}
 	f.setName(name)
-	f.Type = 'N'
+	f.Type = typ
 	f.Len = byte(length)
 	f.Dec = byte(dec)
 	return f, nil
 }
 
+func newDoubleField(name string, dec int) (*field, error) {
+	if err := checkName(name); err != nil {
+		return nil, err
+	}
+	if dec < 0 || dec > maxDoubleDec {
+		return nil, fmt.Errorf("field dec %d, want 0 <= dec <= %d", dec, maxDoubleDec)
+	}
+	f := &field{}
+	f.setName(name)
+	f.Type = 'B'
+	f.Len = doubleLen
+	f.Dec = byte(dec)
+	return f, nil
+}
+
+func newVarField(name string, typ byte, length int) (*field, error) {
+	if err := checkName(name); err != nil {
+		return nil, err
+	}
+	if length <= 0 || length > maxCharacterLen {
+		return nil, fmt.Errorf("field len %d, want 0 < len <= %d", length, maxCharacterLen)
+	}
+	f := &field{}
+	f.setName(name)
+	f.Type = typ
+	f.Len = byte(length)
+	return f, nil
+}
+
+func newIntegerField(name string) (*field, error) {
+	if err := checkName(name); err != nil {
+		return nil, err
+	}
+	f := &field{}
+	f.setName(name)
+	f.Type = 'I'
+	f.Len = integerLen
+	return f, nil
+}
+
+func newAutoIncrementField(name string, start int64, step int) (*field, error) {
+	if start < math.MinInt32 || start > math.MaxInt32 {
+		return nil, fmt.Errorf("start value %d, want %d <= value <= %d", start, math.MinInt32, math.MaxInt32)
+	}
+	if step < 1 || step > maxAutoIncStep {
+		return nil, fmt.Errorf("step %d, want 1 <= step <= %d", step, maxAutoIncStep)
+	}
+	f, err := newIntegerField(name)
+	if err != nil {
+		return nil, err
+	}
+	f.Flags = byte(FlagAutoIncrement)
+	f.AutoIncNext = uint32(int32(start))
+	f.AutoIncStep = byte(step)
+	return f, nil
+}
+
+func newCurrencyField(name string) (*field, error) {
+	if err := checkName(name); err != nil {
+		return nil, err
+	}
+	f := &field{}
+	f.setName(name)
+	f.Type = 'Y'
+	f.Len = currencyLen
+	f.Dec = currencyDec
+	return f, nil
+}
+
+func newDateTimeField(name string) (*field, error) {
+	if err := checkName(name); err != nil {
+		return nil, err
+	}
+	f := &field{}
+	f.setName(name)
+	f.Type = 'T'
+	f.Len = dateTimeLen
+	return f, nil
+}
+
+func newMemoField(name string) (*field, error) {
+	if err := checkName(name); err != nil {
+		return nil, err
+	}
+	f := &field{}
+	f.setName(name)
+	f.Type = 'M'
+	f.Len = memoLen
+	return f, nil
+}
+
+func newBinaryMemoField(name string, typ byte) (*field, error) {
+	if err := checkName(name); err != nil {
+		return nil, err
+	}
+	f := &field{}
+	f.setName(name)
+	f.Type = typ
+	f.Len = memoLen
+	return f, nil
+}
+
 // Field name
 
 func checkName(name string) error {
@@ -98,14 +279,17 @@ func checkName(name string) error {
 	if len(name) == 0 {
 		return fmt.Errorf("empty field name")
 	}
-	if len(name) > maxNameLen {
-		return fmt.Errorf("too long field name %q, max len %d", name, maxNameLen)
+	if len(name) > maxLevel7NameLen {
+		return fmt.Errorf("too long field name %q, max len %d", name, maxLevel7NameLen)
 	}
 	return nil
 }
 
 func (f *field) name() string {
 	i := bytes.IndexByte(f.Name[:], 0)
+	if i < 0 {
+		i = len(f.Name)
+	}
 	return string(f.Name[:i])
 }
 
@@ -116,31 +300,197 @@ func (f *field) setName(name string) {
 
 // Read/write
 
-func (f *field) read(reader io.Reader) error {
-	return binary.Read(reader, binary.LittleEndian, f)
+func (f *field) read(reader io.Reader, version Version) error {
+	d := fieldDescriptor{}
+	if err := binary.Read(reader, binary.LittleEndian, &d); err != nil {
+		return err
+	}
+	*f = field{
+		Type: d.Type,
+		Len:  d.Len,
+		Dec:  d.Dec,
+	}
+	copy(f.Name[:], d.Name[:])
+	// The other versions reserve these bytes, they may contain garbage
+	if version == VisualFoxPro {
+		f.Offset = d.Offset
+		f.Flags = d.Flags
+		f.AutoIncNext = d.AutoIncNext
+		f.AutoIncStep = d.AutoIncStep
+	}
+	return nil
 }
 
-func (f *field) write(writer io.Writer) error {
-	tmp := f.Offset
-	f.Offset = 0
-	defer func() { f.Offset = tmp }()
-	return binary.Write(writer, binary.LittleEndian, f)
+func (f *field) readLevel7(reader io.Reader) error {
+	d := level7FieldDescriptor{}
+	if err := binary.Read(reader, binary.LittleEndian, &d); err != nil {
+		return err
+	}
+	*f = field{
+		Name:        d.Name,
+		Type:        d.Type,
+		Len:         d.Len,
+		Dec:         d.Dec,
+		AutoIncNext: d.AutoIncNext,
+		level7:      true,
+	}
+	return nil
+}
+
+func (f *field) write(writer io.Writer, version Version) error {
+	if version == DBase7 {
+		d := level7FieldDescriptor{
+			Name:        f.Name,
+			Type:        f.Type,
+			Len:         f.Len,
+			Dec:         f.Dec,
+			AutoIncNext: f.AutoIncNext,
+		}
+		return binary.Write(writer, binary.LittleEndian, &d)
+	}
+	d := fieldDescriptor{
+		Type:        f.Type,
+		Len:         f.Len,
+		Dec:         f.Dec,
+		Flags:       f.Flags,
+		AutoIncNext: f.AutoIncNext,
+		AutoIncStep: f.AutoIncStep,
+	}
+	copy(d.Name[:maxNameLen], f.Name[:])
+	if version == VisualFoxPro {
+		d.Offset = f.Offset
+	}
+	return binary.Write(writer, binary.LittleEndian, &d)
+}
+
+// isBinaryValue reports whether the field value is stored
+// in binary form rather than as text.
+func (f *field) isBinaryValue() bool {
+	switch f.Type {
+	case 'I', 'Y', 'T', 'V', 'Q', '0', '+', '@', 'O':
+		return true
+	case 'B':
+		return !f.level7
+	case 'M', 'G', 'P', 'W':
+		return f.Len == visualFoxProMemoLen
+	}
+	return false
+}
+
+// Field flags
+
+func (f *field) flags() FieldFlags {
+	return FieldFlags(f.Flags)
+}
+
+func (f *field) isBinary() bool {
+	return f.flags()&FlagBinary != 0
+}
+
+func (f *field) isAutoIncrement() bool {
+	return f.Type == '+' || f.Type == 'I' && f.flags()&FlagAutoIncrement == FlagAutoIncrement
+}
+
+// setAutoIncrementValue assigns the next value to the field
+// and advances the next value by the step.
+func (f *field) setAutoIncrementValue(recordBuf []byte) error {
+	value := int64(int32(f.AutoIncNext))
+	if err := f.setIntFieldValue(recordBuf, value); err != nil {
+		return err
+	}
+	step := int64(f.AutoIncStep)
+	if f.Type == '+' {
+		step = 1
+	}
+	next := value + step
+	if next > math.MaxInt32 {
+		return fmt.Errorf("field %q autoincrement %w: next value %d", f.name(), ErrFieldOverflow, next)
+	}
+	f.AutoIncNext = uint32(int32(next))
+	return nil
 }
 
 // Check field
 
 func (f *field) checkLen(value string) error {
 	if len(value) > int(f.Len) {
-		return fmt.Errorf("field value %q overflow: value len %d, field len %d", value, len(value), int(f.Len))
+		return fmt.Errorf("field value %q %w: value len %d, field len %d", value, ErrFieldOverflow, len(value), int(f.Len))
 	}
 	return nil
 }
 
-func (f *field) checkType(t byte) error {
-	if t != f.Type {
-		return fmt.Errorf("field type %q, want: %q", f.Type, t)
+func (f *field) checkType(types ...byte) error {
+	for _, t := range types {
+		if t == f.Type {
+			return nil
+		}
 	}
-	return nil
+	want := make([]string, len(types))
+	for i, t := range types {
+		want[i] = fmt.Sprintf("%q", t)
+	}
+	return fmt.Errorf("%w: field type %q, want: %s", ErrTypeMismatch, f.Type, strings.Join(want, ", "))
+}
+
+func (f *field) checkFloatType() error {
+	if f.isMemo() {
+		return fmt.Errorf("%w: field type %q is memo, want: 'N', 'F', 'B', 'O', 'Y'", ErrTypeMismatch, f.Type)
+	}
+	return f.checkType('N', 'F', 'B', 'O', 'Y')
+}
+
+// Binary numbers
+
+// dBase 7 stores binary numbers big-endian with the sign bit
+// inverted, so the values can be compared as bytes.
+const level7SignBit = 1 << 63
+
+func (f *field) int32Value(buf []byte) int32 {
+	if f.level7 {
+		if isZero(buf) {
+			return 0
+		}
+		return int32(binary.BigEndian.Uint32(buf) ^ level7SignBit>>32)
+	}
+	return int32(binary.LittleEndian.Uint32(buf))
+}
+
+func (f *field) setInt32Value(buf []byte, value int32) {
+	if f.level7 {
+		binary.BigEndian.PutUint32(buf, uint32(value)^level7SignBit>>32)
+		return
+	}
+	binary.LittleEndian.PutUint32(buf, uint32(value))
+}
+
+func (f *field) float64Value(buf []byte) float64 {
+	if !f.level7 {
+		return math.Float64frombits(binary.LittleEndian.Uint64(buf))
+	}
+	if isZero(buf) {
+		return 0
+	}
+	bits := binary.BigEndian.Uint64(buf)
+	if bits&level7SignBit != 0 {
+		bits ^= level7SignBit
+	} else {
+		bits = ^bits
+	}
+	return math.Float64frombits(bits)
+}
+
+func (f *field) setFloat64Value(buf []byte, value float64) {
+	bits := math.Float64bits(value)
+	if !f.level7 {
+		binary.LittleEndian.PutUint64(buf, bits)
+		return
+	}
+	if bits&level7SignBit == 0 {
+		bits ^= level7SignBit
+	} else {
+		bits = ^bits
+	}
+	binary.BigEndian.PutUint64(buf, bits)
 }
 
 // Get field value
@@ -155,17 +505,41 @@ func (f *field) stringFieldValue(recordBuf []byte, decoder *encoding.Decoder) (s
 	case 'C':
 		var err error
 		s := trimRight(buf)
-		if decoder != nil && !isASCII(s) {
+		if decoder != nil && !f.isBinary() && !isASCII(s) {
 			s, err = decoder.String(s)
 			if err != nil {
 				return "", err
 			}
 		}
 		return s, nil
-	case 'L', 'D', 'N':
+	case 'L', 'D', 'N', 'F':
 		return trimLeft(buf), nil
+	case 'B', 'O':
+		v, err := f.floatFieldValue(recordBuf)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatFloat(v, 'f', int(f.Dec), 64), nil
+	case 'I', '+':
+		n, err := f.intFieldValue(recordBuf)
+		if err != nil {
+			return "", err
+		}
+		return strconv.FormatInt(n, 10), nil
+	case 'Y':
+		n, err := f.currencyFieldValue(recordBuf)
+		if err != nil {
+			return "", err
+		}
+		return formatCurrency(n), nil
+	case 'T', '@':
+		d, err := f.dateTimeFieldValue(recordBuf)
+		if err != nil || d.IsZero() {
+			return "", err
+		}
+		return d.Format("20060102150405"), nil
 	}
-	return "", fmt.Errorf("unknow type %q, want 'C', 'L', 'D', 'N'", f.Type)
+	return "", fmt.Errorf("%w: unknow type %q, want 'C', 'L', 'D', 'N', 'F', 'B', 'I', 'Y', 'T', '+', '@', 'O'", ErrTypeMismatch, f.Type)
 }
 
 func (f *field) boolFieldValue(recordBuf []byte) (bool, error) {
@@ -192,10 +566,13 @@ func (f *field) dateFieldValue(recordBuf []byte) (time.Time, error) {
 }
 
 func (f *field) intFieldValue(recordBuf []byte) (int64, error) {
-	if err := f.checkType('N'); err != nil {
+	if err := f.checkType('N', 'F', 'I', '+'); err != nil {
 		return 0, err
 	}
 	buf := f.fieldBuf(recordBuf)
+	if f.Type == 'I' || f.Type == '+' {
+		return int64(f.int32Value(buf)), nil
+	}
 	if f.Dec != 0 {
 		buf = buf[:len(buf)-int(f.Dec)-1]
 	}
@@ -207,10 +584,17 @@ func (f *field) intFieldValue(recordBuf []byte) (int64, error) {
 }
 
 func (f *field) floatFieldValue(recordBuf []byte) (float64, error) {
-	if err := f.checkType('N'); err != nil {
+	if err := f.checkFloatType(); err != nil {
 		return 0, err
 	}
 	buf := f.fieldBuf(recordBuf)
+	switch f.Type {
+	case 'Y':
+		n, err := f.currencyFieldValue(recordBuf)
+		return float64(n) / currencyScale, err
+	case 'B', 'O':
+		return f.float64Value(buf), nil
+	}
 	s := trimLeft(buf)
 	if s == "" {
 		s = "0"
@@ -218,8 +602,229 @@ func (f *field) floatFieldValue(recordBuf []byte) (float64, error) {
 	return strconv.ParseFloat(s, 64)
 }
 
+// Currency field value
+
+func (f *field) currencyFieldValue(recordBuf []byte) (int64, error) {
+	if err := f.checkType('Y'); err != nil {
+		return 0, err
+	}
+	return int64(binary.LittleEndian.Uint64(f.fieldBuf(recordBuf))), nil
+}
+
+func (f *field) setCurrencyFieldValue(recordBuf []byte, value int64) error {
+	if err := f.checkType('Y'); err != nil {
+		return err
+	}
+	binary.LittleEndian.PutUint64(f.fieldBuf(recordBuf), uint64(value))
+	return nil
+}
+
+// formatCurrency formats the value scaled by 10000
+// as a decimal number with 4 decimal places.
+func formatCurrency(value int64) string {
+	sign := ""
+	n := uint64(value)
+	if value < 0 {
+		sign = "-"
+		n = -n
+	}
+	return fmt.Sprintf("%s%d.%04d", sign, n/currencyScale, n%currencyScale)
+}
+
+// parseCurrency parses the decimal number to the value scaled by 10000.
+func parseCurrency(s string) (int64, error) {
+	i := strings.IndexByte(s, '.')
+	if i < 0 {
+		i = len(s)
+		s += "."
+	}
+	frac := s[i+1:]
+	if len(frac) > currencyDec {
+		return 0, fmt.Errorf("currency value %q: too many decimal places, max %d", s, currencyDec)
+	}
+	if len(frac) > 0 && (frac[0] == '+' || frac[0] == '-') {
+		return 0, fmt.Errorf("invalid currency value %q", s)
+	}
+	digits := s[:i] + frac + strings.Repeat("0", currencyDec-len(frac))
+	n, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, err
+	}
+	return n, nil
+}
+
+// DateTime field value
+
+func (f *field) dateTimeFieldValue(recordBuf []byte) (time.Time, error) {
+	if err := f.checkType('T', '@'); err != nil {
+		return time.Time{}, err
+	}
+	buf := f.fieldBuf(recordBuf)
+	if f.Type == '@' {
+		if isZero(buf) {
+			return time.Time{}, nil
+		}
+		ms := int64(f.float64Value(buf)) - julianDayUnixEpoch*secondsPerDay*1000
+		return time.Unix(0, 0).UTC().Add(time.Duration(ms) * time.Millisecond), nil
+	}
+	day := int64(int32(binary.LittleEndian.Uint32(buf)))
+	ms := int64(int32(binary.LittleEndian.Uint32(buf[4:])))
+	if day == 0 && ms == 0 {
+		return time.Time{}, nil
+	}
+	d := time.Unix((day-julianDayUnixEpoch)*secondsPerDay, 0).UTC()
+	return d.Add(time.Duration(ms) * time.Millisecond), nil
+}
+
+func (f *field) setDateTimeFieldValue(recordBuf []byte, value time.Time) error {
+	if err := f.checkType('T', '@'); err != nil {
+		return err
+	}
+	buf := f.fieldBuf(recordBuf)
+	if value.IsZero() {
+		binary.LittleEndian.PutUint64(buf, 0)
+		return nil
+	}
+	// The wall clock time is stored as for Date fields
+	y, m, d := value.Date()
+	h, min, sec := value.Clock()
+	if f.Type == '@' {
+		wall := time.Date(y, m, d, h, min, sec, value.Nanosecond(), time.UTC)
+		ms := wall.Unix()*1000 + int64(wall.Nanosecond())/int64(time.Millisecond)
+		f.setFloat64Value(buf, float64(ms+julianDayUnixEpoch*secondsPerDay*1000))
+		return nil
+	}
+	date := time.Date(y, m, d, 0, 0, 0, 0, time.UTC)
+	day := date.Unix()/secondsPerDay + julianDayUnixEpoch
+	ms := (h*60+min)*60*1000 + sec*1000 + value.Nanosecond()/int(time.Millisecond)
+	binary.LittleEndian.PutUint32(buf, uint32(day))
+	binary.LittleEndian.PutUint32(buf[4:], uint32(ms))
+	return nil
+}
+
+// Memo field value
+
+func (f *field) isMemo() bool {
+	switch f.Type {
+	case 'M', 'G', 'P', 'W':
+		return true
+	case 'B':
+		// dBase 7 binary memo
+		return f.level7
+	}
+	return false
+}
+
+func (f *field) memoBlock(recordBuf []byte) (uint32, error) {
+	buf := f.fieldBuf(recordBuf)
+	if len(buf) == 4 {
+		return binary.LittleEndian.Uint32(buf), nil
+	}
+	s := strings.TrimSpace(string(buf))
+	if s == "" {
+		return 0, nil
+	}
+	n, err := strconv.ParseUint(s, 10, 32)
+	if err != nil {
+		return 0, err
+	}
+	return uint32(n), nil
+}
+
+func (f *field) setMemoBlock(recordBuf []byte, block uint32) {
+	buf := f.fieldBuf(recordBuf)
+	if len(buf) == 4 {
+		binary.LittleEndian.PutUint32(buf, block)
+		return
+	}
+	s := ""
+	if block != 0 {
+		s = strconv.FormatUint(uint64(block), 10)
+	}
+	f.setFieldBuf(recordBuf, padLeft(s, int(f.Len)))
+}
+
+func (f *field) memoBytesFieldValue(recordBuf []byte, memo *memoReader) ([]byte, error) {
+	block, err := f.memoBlock(recordBuf)
+	if err != nil {
+		return nil, err
+	}
+	if block == 0 {
+		return nil, nil
+	}
+	if memo == nil {
+		return nil, fmt.Errorf("memo file not set")
+	}
+	return memo.read(block)
+}
+
+func (f *field) memoFieldValue(recordBuf []byte, memo *memoReader, decoder *encoding.Decoder) (string, error) {
+	if err := f.checkType('M'); err != nil {
+		return "", err
+	}
+	data, err := f.memoBytesFieldValue(recordBuf, memo)
+	if err != nil {
+		return "", err
+	}
+	s := string(data)
+	if decoder != nil && !f.isBinary() && !isASCII(s) {
+		s, err = decoder.String(s)
+		if err != nil {
+			return "", err
+		}
+	}
+	return s, nil
+}
+
+func (f *field) setMemoBytesFieldValue(recordBuf []byte, value []byte, memo *memoWriter) error {
+	if len(value) == 0 {
+		f.setMemoBlock(recordBuf, 0)
+		return nil
+	}
+	if memo == nil {
+		return fmt.Errorf("memo file not set")
+	}
+	typ := memoPicture
+	if f.Type == 'M' {
+		typ = memoText
+	}
+	block, err := memo.write(value, typ)
+	if err != nil {
+		return err
+	}
+	f.setMemoBlock(recordBuf, block)
+	return nil
+}
+
+func (f *field) setMemoFieldValue(recordBuf []byte, value string, memo *memoWriter, encoder *encoding.Encoder) error {
+	if err := f.checkType('M'); err != nil {
+		return err
+	}
+	var err error
+	s := value
+	if encoder != nil && !f.isBinary() && !isASCII(s) {
+		s, err = encoder.String(s)
+		if err != nil {
+			return err
+		}
+	}
+	return f.setMemoBytesFieldValue(recordBuf, []byte(s), memo)
+}
+
 // Set field value
 
+// clear sets the blank value of the field.
+func (f *field) clear(recordBuf []byte) {
+	buf := f.fieldBuf(recordBuf)
+	blank := byte(' ')
+	if f.isBinaryValue() {
+		blank = 0
+	}
+	for i := range buf {
+		buf[i] = blank
+	}
+}
+
 func (f *field) setFieldBuf(recordBuf []byte, value string) {
 	copy(recordBuf[int(f.Offset):int(f.Offset)+int(f.Len)], value)
 }
@@ -229,7 +834,7 @@ func (f *field) setStringFieldValue(recordBuf []byte, value string, encoder *enc
 	case 'C':
 		var err error
 		s := value
-		if encoder != nil && !isASCII(s) {
+		if encoder != nil && !f.isBinary() && !isASCII(s) {
 			s, err = encoder.String(s)
 			if err != nil {
 				return err
@@ -264,7 +869,37 @@ func (f *field) setStringFieldValue(recordBuf []byte, value string, encoder *enc
 			}
 		}
 		f.setFieldBuf(recordBuf, s)
-	case 'N':
+	case 'Y':
+		s := strings.TrimSpace(value)
+		if s == "" {
+			s = "0"
+		}
+		n, err := parseCurrency(s)
+		if err != nil {
+			return err
+		}
+		return f.setCurrencyFieldValue(recordBuf, n)
+	case 'T', '@':
+		s := strings.TrimSpace(value)
+		if s == "" {
+			return f.setDateTimeFieldValue(recordBuf, time.Time{})
+		}
+		d, err := time.Parse("20060102150405", s)
+		if err != nil {
+			return err
+		}
+		return f.setDateTimeFieldValue(recordBuf, d)
+	case 'B', 'O':
+		s := strings.TrimSpace(value)
+		if s == "" {
+			s = "0"
+		}
+		n, err := strconv.ParseFloat(s, 64)
+		if err != nil {
+			return err
+		}
+		return f.setFloatFieldValue(recordBuf, n)
+	case 'N', 'F', 'I', '+':
 		s := strings.TrimSpace(value)
 		if s == "" {
 			s = "0"
@@ -283,7 +918,7 @@ func (f *field) setStringFieldValue(recordBuf []byte, value string, encoder *enc
 			return f.setFloatFieldValue(recordBuf, n)
 		}
 	default:
-		return fmt.Errorf("unknow type %q, want 'C', 'L', 'D', 'N'", f.Type)
+		return fmt.Errorf("%w: unknow type %q, want 'C', 'L', 'D', 'N', 'F', 'B', 'I', 'Y', 'T', '+', '@', 'O'", ErrTypeMismatch, f.Type)
 	}
 	return nil
 }
@@ -310,9 +945,16 @@ func (f *field) setDateFieldValue(recordBuf []byte, value time.Time) error {
 }
 
 func (f *field) setIntFieldValue(recordBuf []byte, value int64) error {
-	if err := f.checkType('N'); err != nil {
+	if err := f.checkType('N', 'F', 'I', '+'); err != nil {
 		return err
 	}
+	if f.Type == 'I' || f.Type == '+' {
+		if value < math.MinInt32 || value > math.MaxInt32 {
+			return fmt.Errorf("field value %d %w: want %d <= value <= %d", value, ErrFieldOverflow, math.MinInt32, math.MaxInt32)
+		}
+		f.setInt32Value(f.fieldBuf(recordBuf), int32(value))
+		return nil
+	}
 	s := strconv.FormatInt(value, 10)
 	if f.Dec > 0 {
 		s += "." + strings.Repeat("0", int(f.Dec))
@@ -326,9 +968,20 @@ func (f *field) setIntFieldValue(recordBuf []byte, value int64) error {
 }
 
 func (f *field) setFloatFieldValue(recordBuf []byte, value float64) error {
-	if err := f.checkType('N'); err != nil {
+	if err := f.checkFloatType(); err != nil {
 		return err
 	}
+	switch f.Type {
+	case 'Y':
+		n := math.Round(value * currencyScale)
+		if math.IsNaN(n) || n < math.MinInt64 || n >= math.MaxInt64 {
+			return fmt.Errorf("field value %v %w", value, ErrFieldOverflow)
+		}
+		return f.setCurrencyFieldValue(recordBuf, int64(n))
+	case 'B', 'O':
+		f.setFloat64Value(f.fieldBuf(recordBuf), value)
+		return nil
+	}
 	s := strconv.FormatFloat(value, 'f', int(f.Dec), 64)
 	if err := f.checkLen(s); err != nil {
 		return err
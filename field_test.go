@@ -2,7 +2,10 @@ package dbf
 
 import (
 	"bytes"
+	"errors"
+	"math"
 	"reflect"
+	"strconv"
 	"testing"
 	"time"
 
@@ -91,7 +94,7 @@ func Test_newNumericField(t *testing.T) {
 
 func Test_field_name(t *testing.T) {
 	f := &field{
-		Name: [11]byte{'N', 'A', 'M', 'E', 0, 0, 0, 0, 0, 0},
+		Name: [32]byte{'N', 'A', 'M', 'E'},
 	}
 	if f.name() != "NAME" {
 		t.Errorf("field.name(): want: %#v, got: %#v", "NAME", f.name())
@@ -113,7 +116,7 @@ var fieldBytes = []byte{'N', 'A', 'M', 'E', 0, 0, 0, 0, 0, 0, 0, 'C', 0, 0, 0, 0
 
 func Test_field_read(t *testing.T) {
 	f := &field{}
-	err := f.read(bytes.NewReader(fieldBytes))
+	err := f.read(bytes.NewReader(fieldBytes), DBase3)
 
 	if err != nil {
 		t.Errorf("field.read(): %v", err)
@@ -139,7 +142,7 @@ func Test_field_write(t *testing.T) {
 	f, _ := newCharacterField("name", 14)
 
 	buf := bytes.NewBuffer(nil)
-	err := f.write(buf)
+	err := f.write(buf, DBase3)
 
 	if err != nil {
 		t.Errorf("field.write(): %v", err)
@@ -591,3 +594,437 @@ func Test_field_check_type(t *testing.T) {
 		t.Errorf("field check type: error requered")
 	}
 }
+
+func Test_newMemoField(t *testing.T) {
+	f, _ := newMemoField("Note")
+
+	tpl := "newMemoField('Note'): %s: want: %v, got: %v"
+
+	if f.name() != "NOTE" {
+		t.Errorf(tpl, "f.name()", "NOTE", f.name())
+	}
+	if f.Type != 'M' {
+		t.Errorf(tpl, "f.Type", string('M'), string(f.Type))
+	}
+	if f.Len != 10 {
+		t.Errorf(tpl, "f.Len", 10, f.Len)
+	}
+}
+
+func Test_field_memoBlock(t *testing.T) {
+	f, _ := newMemoField("name")
+
+	tests := []struct {
+		buf   []byte
+		want  uint32
+		isErr bool
+	}{
+		{buf: []byte("         5"), want: 5, isErr: false},
+		{buf: []byte("0000000012"), want: 12, isErr: false},
+		{buf: []byte("          "), want: 0, isErr: false},
+		{buf: []byte("abc       "), want: 0, isErr: true},
+	}
+	for _, tc := range tests {
+		got, err := f.memoBlock(tc.buf)
+		gotErr := (err != nil)
+
+		if tc.isErr != gotErr {
+			t.Errorf("field.memoBlock(%#v): want error: %v, got error: %v", string(tc.buf), tc.isErr, gotErr)
+		}
+		if tc.want != got {
+			t.Errorf("field.memoBlock(%#v): want: %#v, got: %#v", string(tc.buf), tc.want, got)
+		}
+	}
+}
+
+// Integer field
+
+func Test_newIntegerField(t *testing.T) {
+	f, _ := newIntegerField("Id")
+
+	tpl := "newIntegerField('Id'): %s: want: %v, got: %v"
+
+	if f.name() != "ID" {
+		t.Errorf(tpl, "f.name()", "ID", f.name())
+	}
+	if f.Type != 'I' {
+		t.Errorf(tpl, "f.Type", string('I'), string(f.Type))
+	}
+	if f.Len != 4 {
+		t.Errorf(tpl, "f.Len", 4, f.Len)
+	}
+}
+
+func Test_field_intFieldValue_I(t *testing.T) {
+	f, _ := newIntegerField("name")
+
+	tests := []struct {
+		buf  []byte
+		want int64
+	}{
+		{buf: []byte{0x7B, 0, 0, 0}, want: 123},
+		{buf: []byte{0x85, 0xFF, 0xFF, 0xFF}, want: -123},
+		{buf: []byte{0, 0, 0, 0}, want: 0},
+		{buf: []byte{0xFF, 0xFF, 0xFF, 0x7F}, want: 2147483647},
+	}
+	for _, tc := range tests {
+		got, err := f.intFieldValue(tc.buf)
+		if err != nil {
+			t.Errorf("field.intFieldValue(%#v): %v", tc.buf, err)
+		}
+		if tc.want != got {
+			t.Errorf("field.intFieldValue(%#v): want: %#v, got: %#v", tc.buf, tc.want, got)
+		}
+		s, _ := f.stringFieldValue(tc.buf, nil)
+		if want := strconv.FormatInt(tc.want, 10); s != want {
+			t.Errorf("field.stringFieldValue(%#v): want: %#v, got: %#v", tc.buf, want, s)
+		}
+	}
+}
+
+func Test_field_setIntFieldValue_I(t *testing.T) {
+	f, _ := newIntegerField("name")
+
+	tests := []struct {
+		value int64
+		want  []byte
+		isErr bool
+	}{
+		{value: 123, want: []byte{0x7B, 0, 0, 0}, isErr: false},
+		{value: -123, want: []byte{0x85, 0xFF, 0xFF, 0xFF}, isErr: false},
+		{value: 1 << 31, want: []byte{0, 0, 0, 0}, isErr: true},
+	}
+	for _, tc := range tests {
+		buf := make([]byte, 4)
+		err := f.setIntFieldValue(buf, tc.value)
+		gotErr := (err != nil)
+
+		if tc.isErr != gotErr {
+			t.Errorf("field.setIntFieldValue(%#v): want error: %v, got error: %v", tc.value, tc.isErr, gotErr)
+		}
+		if !bytes.Equal(buf, tc.want) {
+			t.Errorf("field.setIntFieldValue(%#v): want: %#v, got: %#v", tc.value, tc.want, buf)
+		}
+	}
+}
+
+func Test_field_setStringFieldValue_I(t *testing.T) {
+	f, _ := newIntegerField("name")
+
+	buf := make([]byte, 4)
+	if err := f.setStringFieldValue(buf, " -2 ", nil); err != nil {
+		t.Errorf("field.setStringFieldValue(): %v", err)
+	}
+	want := []byte{0xFE, 0xFF, 0xFF, 0xFF}
+	if !bytes.Equal(buf, want) {
+		t.Errorf("field.setStringFieldValue(): want: %#v, got: %#v", want, buf)
+	}
+}
+
+// Currency field
+
+func Test_parseCurrency(t *testing.T) {
+	tests := []struct {
+		value string
+		want  int64
+		isErr bool
+	}{
+		{value: "12.5", want: 125000, isErr: false},
+		{value: "-0.0001", want: -1, isErr: false},
+		{value: "-.5", want: -5000, isErr: false},
+		{value: "7", want: 70000, isErr: false},
+		{value: "922337203685477.5807", want: 9223372036854775807, isErr: false},
+		{value: "1.23456", want: 0, isErr: true},
+		{value: "1.-5", want: 0, isErr: true},
+		{value: "abc", want: 0, isErr: true},
+	}
+	for _, tc := range tests {
+		got, err := parseCurrency(tc.value)
+		gotErr := (err != nil)
+
+		if tc.isErr != gotErr {
+			t.Errorf("parseCurrency(%#v): want error: %v, got error: %v", tc.value, tc.isErr, gotErr)
+		}
+		if tc.want != got {
+			t.Errorf("parseCurrency(%#v): want: %#v, got: %#v", tc.value, tc.want, got)
+		}
+	}
+}
+
+func Test_formatCurrency(t *testing.T) {
+	tests := []struct {
+		value int64
+		want  string
+	}{
+		{value: 125000, want: "12.5000"},
+		{value: -1, want: "-0.0001"},
+		{value: 0, want: "0.0000"},
+		{value: -9223372036854775808, want: "-922337203685477.5808"},
+	}
+	for _, tc := range tests {
+		got := formatCurrency(tc.value)
+		if tc.want != got {
+			t.Errorf("formatCurrency(%#v): want: %#v, got: %#v", tc.value, tc.want, got)
+		}
+	}
+}
+
+func Test_field_currency(t *testing.T) {
+	f, _ := newCurrencyField("price")
+
+	if f.Type != 'Y' || f.Len != 8 || f.Dec != 4 {
+		t.Errorf("newCurrencyField(): want: Y 8 4, got: %s %v %v", string(f.Type), f.Len, f.Dec)
+	}
+
+	buf := make([]byte, 8)
+	if err := f.setStringFieldValue(buf, "-1234.5678", nil); err != nil {
+		t.Errorf("field.setStringFieldValue(): %v", err)
+	}
+	if n, _ := f.currencyFieldValue(buf); n != -12345678 {
+		t.Errorf("field.currencyFieldValue(): want: %v, got: %v", -12345678, n)
+	}
+	if s, _ := f.stringFieldValue(buf, nil); s != "-1234.5678" {
+		t.Errorf("field.stringFieldValue(): want: %#v, got: %#v", "-1234.5678", s)
+	}
+	if v, _ := f.floatFieldValue(buf); v != -1234.5678 {
+		t.Errorf("field.floatFieldValue(): want: %v, got: %v", -1234.5678, v)
+	}
+	f.setFloatFieldValue(buf, 0.1)
+	if n, _ := f.currencyFieldValue(buf); n != 1000 {
+		t.Errorf("field.setFloatFieldValue(0.1): want: %v, got: %v", 1000, n)
+	}
+	for _, v := range []float64{math.NaN(), math.Inf(1), math.Inf(-1), 1e15} {
+		if err := f.setFloatFieldValue(buf, v); !errors.Is(err, ErrFieldOverflow) {
+			t.Errorf("field.setFloatFieldValue(%v): want: %v, got: %v", v, ErrFieldOverflow, err)
+		}
+	}
+}
+
+// DateTime field
+
+func Test_field_dateTimeFieldValue(t *testing.T) {
+	f, _ := newDateTimeField("name")
+
+	tests := []struct {
+		buf  []byte
+		want time.Time
+	}{
+		// 2021-02-12 (JDN 2459258) 13:45:30.250
+		{buf: []byte{0x7A, 0x86, 0x25, 0x00, 0x8A, 0xC5, 0xF3, 0x02}, want: time.Date(2021, 2, 12, 13, 45, 30, 250e6, time.UTC)},
+		// 1970-01-01 00:00:00
+		{buf: []byte{0x8C, 0x3D, 0x25, 0x00, 0, 0, 0, 0}, want: time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{buf: make([]byte, 8), want: time.Time{}},
+	}
+	for _, tc := range tests {
+		got, err := f.dateTimeFieldValue(tc.buf)
+		if err != nil {
+			t.Errorf("field.dateTimeFieldValue(%#v): %v", tc.buf, err)
+		}
+		if !tc.want.Equal(got) {
+			t.Errorf("field.dateTimeFieldValue(%#v): want: %v, got: %v", tc.buf, tc.want, got)
+		}
+
+		buf := make([]byte, 8)
+		f.setDateTimeFieldValue(buf, tc.want)
+		if !bytes.Equal(buf, tc.buf) {
+			t.Errorf("field.setDateTimeFieldValue(%v): want: %#v, got: %#v", tc.want, tc.buf, buf)
+		}
+	}
+}
+
+func Test_field_setDateTimeFieldValue_truncate(t *testing.T) {
+	f, _ := newDateTimeField("name")
+	d := time.Date(1899, 12, 30, 23, 59, 59, 999999999, time.UTC)
+
+	buf := make([]byte, 8)
+	f.setDateTimeFieldValue(buf, d)
+	got, _ := f.dateTimeFieldValue(buf)
+
+	want := d.Truncate(time.Millisecond)
+	if !want.Equal(got) {
+		t.Errorf("field.setDateTimeFieldValue(%v): want: %v, got: %v", d, want, got)
+	}
+	if s, _ := f.stringFieldValue(buf, nil); s != "18991230235959" {
+		t.Errorf("field.stringFieldValue(): want: %#v, got: %#v", "18991230235959", s)
+	}
+}
+
+// Float and Double fields
+
+func Test_field_float_F(t *testing.T) {
+	f, _ := newFloatField("name", 10, 3)
+
+	if f.Type != 'F' || f.Len != 10 || f.Dec != 3 {
+		t.Errorf("newFloatField(): want: F 10 3, got: %s %v %v", string(f.Type), f.Len, f.Dec)
+	}
+
+	buf := []byte("xxxxxxxxxx")
+	if err := f.setFloatFieldValue(buf, -12.5); err != nil {
+		t.Errorf("field.setFloatFieldValue(): %v", err)
+	}
+	if string(buf) != "   -12.500" {
+		t.Errorf("field.setFloatFieldValue(): want: %#v, got: %#v", "   -12.500", string(buf))
+	}
+	if v, _ := f.floatFieldValue(buf); v != -12.5 {
+		t.Errorf("field.floatFieldValue(): want: %v, got: %v", -12.5, v)
+	}
+	if s, _ := f.stringFieldValue(buf, nil); s != "-12.500" {
+		t.Errorf("field.stringFieldValue(): want: %#v, got: %#v", "-12.500", s)
+	}
+	if _, err := newFloatField("name", 21, 0); err == nil {
+		t.Errorf("newFloatField(21): error required")
+	}
+}
+
+func Test_field_float_B(t *testing.T) {
+	f, _ := newDoubleField("name", 2)
+
+	if f.Type != 'B' || f.Len != 8 || f.Dec != 2 {
+		t.Errorf("newDoubleField(): want: B 8 2, got: %s %v %v", string(f.Type), f.Len, f.Dec)
+	}
+
+	buf := make([]byte, 8)
+	if err := f.setFloatFieldValue(buf, 3.14159); err != nil {
+		t.Errorf("field.setFloatFieldValue(): %v", err)
+	}
+	want := []byte{0x6E, 0x86, 0x1B, 0xF0, 0xF9, 0x21, 0x09, 0x40}
+	if !bytes.Equal(buf, want) {
+		t.Errorf("field.setFloatFieldValue(): want: %#v, got: %#v", want, buf)
+	}
+	if v, _ := f.floatFieldValue(buf); v != 3.14159 {
+		t.Errorf("field.floatFieldValue(): want: %v, got: %v", 3.14159, v)
+	}
+	if s, _ := f.stringFieldValue(buf, nil); s != "3.14" {
+		t.Errorf("field.stringFieldValue(): want: %#v, got: %#v", "3.14", s)
+	}
+	f.setStringFieldValue(buf, " -1.5 ", nil)
+	if v, _ := f.floatFieldValue(buf); v != -1.5 {
+		t.Errorf("field.setStringFieldValue(): want: %v, got: %v", -1.5, v)
+	}
+	if _, err := f.intFieldValue(buf); err == nil {
+		t.Errorf("field.intFieldValue(): double field: error required")
+	}
+}
+
+func Test_newAutoIncrementField(t *testing.T) {
+	f, err := newAutoIncrementField("Id", 10, 5)
+	if err != nil {
+		t.Fatalf("newAutoIncrementField('Id', 10, 5): %v", err)
+	}
+
+	tpl := "newAutoIncrementField('Id', 10, 5): %s: want: %v, got: %v"
+
+	if f.Type != 'I' {
+		t.Errorf(tpl, "f.Type", string('I'), string(f.Type))
+	}
+	if !f.isAutoIncrement() {
+		t.Errorf(tpl, "f.isAutoIncrement()", true, false)
+	}
+	if f.AutoIncNext != 10 {
+		t.Errorf(tpl, "f.AutoIncNext", 10, f.AutoIncNext)
+	}
+	if f.AutoIncStep != 5 {
+		t.Errorf(tpl, "f.AutoIncStep", 5, f.AutoIncStep)
+	}
+
+	buf := make([]byte, 5)
+	for _, want := range []int64{10, 15, 20} {
+		if err := f.setAutoIncrementValue(buf); err != nil {
+			t.Fatalf("f.setAutoIncrementValue(): %v", err)
+		}
+		if got, _ := f.intFieldValue(buf); got != want {
+			t.Errorf("f.setAutoIncrementValue(): want: %v, got: %v", want, got)
+		}
+	}
+
+	for _, tc := range []struct {
+		start int64
+		step  int
+	}{
+		{0, 0},
+		{0, 256},
+		{math.MaxInt32 + 1, 1},
+	} {
+		if _, err := newAutoIncrementField("Id", tc.start, tc.step); err == nil {
+			t.Errorf("newAutoIncrementField('Id', %d, %d): error required", tc.start, tc.step)
+		}
+	}
+}
+
+func Test_field_level7_binary(t *testing.T) {
+	f := &field{Type: 'I', Len: 4, Offset: 1, level7: true}
+	buf := make([]byte, 5)
+
+	tests := []struct {
+		value int64
+		want  []byte
+	}{
+		{value: 1, want: []byte{0x80, 0, 0, 1}},
+		{value: -1, want: []byte{0x7F, 0xFF, 0xFF, 0xFF}},
+	}
+	for _, tc := range tests {
+		f.setIntFieldValue(buf, tc.value)
+		if !reflect.DeepEqual(buf[1:], tc.want) {
+			t.Errorf("field.setIntFieldValue(%d): want: %#v, got: %#v", tc.value, tc.want, buf[1:])
+		}
+		if got, _ := f.intFieldValue(buf); got != tc.value {
+			t.Errorf("field.intFieldValue(): want: %v, got: %v", tc.value, got)
+		}
+	}
+
+	f = &field{Type: 'O', Len: 8, Offset: 1, level7: true}
+	buf = make([]byte, 9)
+	for _, value := range []float64{1.5, -2.25, 0} {
+		f.setFloatFieldValue(buf, value)
+		if got, _ := f.floatFieldValue(buf); got != value {
+			t.Errorf("field.floatFieldValue(): want: %v, got: %v", value, got)
+		}
+	}
+	f.setFloatFieldValue(buf, 1)
+	if want := []byte{0xBF, 0xF0, 0, 0, 0, 0, 0, 0}; !reflect.DeepEqual(buf[1:], want) {
+		t.Errorf("field.setFloatFieldValue(1): want: %#v, got: %#v", want, buf[1:])
+	}
+}
+
+func Test_field_write_level7(t *testing.T) {
+	f, _ := newCharacterField("customer_name", 14)
+
+	buf := bytes.NewBuffer(nil)
+	if err := f.write(buf, DBase7); err != nil {
+		t.Fatalf("field.write(): %v", err)
+	}
+	if len(buf.Bytes()) != level7FieldSize {
+		t.Fatalf("field.write(): len: want: %v, got: %v", level7FieldSize, len(buf.Bytes()))
+	}
+	g := &field{}
+	if err := g.readLevel7(bytes.NewReader(buf.Bytes())); err != nil {
+		t.Fatalf("field.readLevel7(): %v", err)
+	}
+	if g.name() != "CUSTOMER_NAME" || g.Type != 'C' || g.Len != 14 {
+		t.Errorf("field.readLevel7(): want: %v %q %v, got: %v %q %v", "CUSTOMER_NAME", 'C', 14, g.name(), g.Type, g.Len)
+	}
+}
+
+func Test_field_setDateTimeFieldValue_wall_clock(t *testing.T) {
+	value := time.Date(2021, 2, 12, 1, 30, 15, 250e6, time.FixedZone("MSK", 3*60*60))
+	want := time.Date(2021, 2, 12, 1, 30, 15, 250e6, time.UTC)
+
+	f, _ := newDateTimeField("time")
+	timestamp := *f
+	timestamp.Type = '@'
+	timestamp.level7 = true
+
+	for _, item := range []*field{f, &timestamp} {
+		buf := make([]byte, 1+int(item.Len))
+		if err := item.setDateTimeFieldValue(buf, value); err != nil {
+			t.Fatalf("field.setDateTimeFieldValue(%q): %v", item.Type, err)
+		}
+		got, err := item.dateTimeFieldValue(buf)
+		if err != nil {
+			t.Fatalf("field.dateTimeFieldValue(%q): %v", item.Type, err)
+		}
+		if !got.Equal(want) {
+			t.Errorf("field.dateTimeFieldValue(%q): want: %v, got: %v", item.Type, want, got)
+		}
+	}
+}
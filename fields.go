@@ -1,8 +1,10 @@
 package dbf
 
 import (
+	"bufio"
 	"fmt"
 	"io"
+	"strings"
 	"time"
 
 	"golang.org/x/text/encoding"
@@ -10,14 +12,20 @@ import (
 
 // Fields for creating file structure.
 type Fields struct {
-	items   []*field
-	recSize int
-	err     error
+	items      []*field
+	index      map[string]int
+	recSize    int
+	nullFlags  *field
+	nullBits   []int
+	lengthBits []int
+	// Visual FoxPro fields are null by the null flags only
+	visualFoxPro bool
+	err          error
 }
 
 // NewFields returns a pointer to a structure Fields.
 func NewFields() *Fields {
-	return &Fields{recSize: 1}
+	return &Fields{recSize: 1, index: make(map[string]int)}
 }
 
 // Err returns the first error that was encountered by the Fields.
@@ -39,17 +47,55 @@ func (f *Fields) addItem(item *field) error {
 	}
 	item.Offset = uint32(f.recSize)
 	f.recSize += int(item.Len)
+	f.index[strings.ToUpper(item.name())] = len(f.items)
 	f.items = append(f.items, item)
+	f.layoutNullFlags()
 	return nil
 }
 
-func (f *Fields) nameExists(name string) bool {
+// clone returns a copy of the fields which can be modified
+// without changing f.
+func (f *Fields) clone() *Fields {
+	c := &Fields{
+		items:        make([]*field, len(f.items)),
+		recSize:      f.recSize,
+		visualFoxPro: f.visualFoxPro,
+		err:          f.err,
+	}
+	for i, item := range f.items {
+		copied := *item
+		c.items[i] = &copied
+	}
+	c.updateIndex()
+	c.layoutNullFlags()
+	return c
+}
+
+func (f *Fields) updateIndex() {
+	f.index = make(map[string]int, len(f.items))
+	for i, item := range f.items {
+		f.index[strings.ToUpper(item.name())] = i
+	}
+}
+
+func (f *Fields) updateOffsets() {
+	f.recSize = 1
 	for _, item := range f.items {
-		if item.name() == name {
-			return true
-		}
+		item.Offset = uint32(f.recSize)
+		f.recSize += int(item.Len)
 	}
-	return false
+}
+
+func (f *Fields) nameExists(name string) bool {
+	_, ok := f.index[strings.ToUpper(name)]
+	return ok
+}
+
+// FieldIndex returns the index of the field by name.
+// The name is case insensitive.
+func (f *Fields) FieldIndex(name string) (int, bool) {
+	index, ok := f.index[strings.ToUpper(strings.TrimSpace(name))]
+	return index, ok
 }
 
 // AddLogicalField adds a logical field to the structure.
@@ -116,6 +162,188 @@ func (f *Fields) AddNumericField(name string, length, dec int) {
 	}
 }
 
+// AddFloatField adds a float field to the structure.
+// The value is stored as text like in a numeric field.
+func (f *Fields) AddFloatField(name string, length, dec int) {
+	if f.err != nil {
+		return
+	}
+	item, err := newFloatField(name, length, dec)
+	if err != nil {
+		f.err = fmt.Errorf("AddFloatField: %w", err)
+		return
+	}
+	if err := f.addItem(item); err != nil {
+		f.err = fmt.Errorf("AddFloatField: %w", err)
+		return
+	}
+}
+
+// AddDoubleField adds a double field to the structure.
+// The value is stored as an 8-byte floating point number.
+// The decimal places are used to format the value as a string.
+func (f *Fields) AddDoubleField(name string, dec int) {
+	if f.err != nil {
+		return
+	}
+	item, err := newDoubleField(name, dec)
+	if err != nil {
+		f.err = fmt.Errorf("AddDoubleField: %w", err)
+		return
+	}
+	if err := f.addItem(item); err != nil {
+		f.err = fmt.Errorf("AddDoubleField: %w", err)
+		return
+	}
+}
+
+// AddVarcharField adds a varchar field to the structure.
+// The value is stored with its exact length, trailing spaces are kept.
+func (f *Fields) AddVarcharField(name string, length int) {
+	f.addVarField("AddVarcharField", name, 'V', length)
+}
+
+// AddVarbinaryField adds a varbinary field to the structure.
+// The value is stored with its exact length.
+func (f *Fields) AddVarbinaryField(name string, length int) {
+	f.addVarField("AddVarbinaryField", name, 'Q', length)
+}
+
+func (f *Fields) addVarField(fn, name string, typ byte, length int) {
+	if f.err != nil {
+		return
+	}
+	item, err := newVarField(name, typ, length)
+	if err != nil {
+		f.err = fmt.Errorf("%s: %w", fn, err)
+		return
+	}
+	if err := f.addItem(item); err != nil {
+		f.err = fmt.Errorf("%s: %w", fn, err)
+		return
+	}
+}
+
+// AddIntegerField adds an integer field to the structure.
+// The value is stored as a 4-byte binary integer.
+func (f *Fields) AddIntegerField(name string) {
+	if f.err != nil {
+		return
+	}
+	item, err := newIntegerField(name)
+	if err != nil {
+		f.err = fmt.Errorf("AddIntegerField: %w", err)
+		return
+	}
+	if err := f.addItem(item); err != nil {
+		f.err = fmt.Errorf("AddIntegerField: %w", err)
+		return
+	}
+}
+
+// AddAutoIncrementField adds an autoincrementing integer field to the structure.
+// The Writer assigns the value of the field on each Write,
+// starting from start and increasing by step (1 to 255).
+// The field is supported by Visual FoxPro.
+func (f *Fields) AddAutoIncrementField(name string, start int64, step int) {
+	if f.err != nil {
+		return
+	}
+	item, err := newAutoIncrementField(name, start, step)
+	if err != nil {
+		f.err = fmt.Errorf("AddAutoIncrementField: %w", err)
+		return
+	}
+	if err := f.addItem(item); err != nil {
+		f.err = fmt.Errorf("AddAutoIncrementField: %w", err)
+		return
+	}
+}
+
+// AddCurrencyField adds a currency field to the structure.
+// The value is stored as an 8-byte integer scaled by 10000.
+func (f *Fields) AddCurrencyField(name string) {
+	if f.err != nil {
+		return
+	}
+	item, err := newCurrencyField(name)
+	if err != nil {
+		f.err = fmt.Errorf("AddCurrencyField: %w", err)
+		return
+	}
+	if err := f.addItem(item); err != nil {
+		f.err = fmt.Errorf("AddCurrencyField: %w", err)
+		return
+	}
+}
+
+// AddDateTimeField adds a datetime field to the structure.
+// The value is stored with millisecond precision.
+func (f *Fields) AddDateTimeField(name string) {
+	if f.err != nil {
+		return
+	}
+	item, err := newDateTimeField(name)
+	if err != nil {
+		f.err = fmt.Errorf("AddDateTimeField: %w", err)
+		return
+	}
+	if err := f.addItem(item); err != nil {
+		f.err = fmt.Errorf("AddDateTimeField: %w", err)
+		return
+	}
+}
+
+// AddMemoField adds a memo field to the structure.
+// Memo values are stored in a companion memo file (.DBT).
+func (f *Fields) AddMemoField(name string) {
+	if f.err != nil {
+		return
+	}
+	item, err := newMemoField(name)
+	if err != nil {
+		f.err = fmt.Errorf("AddMemoField: %w", err)
+		return
+	}
+	if err := f.addItem(item); err != nil {
+		f.err = fmt.Errorf("AddMemoField: %w", err)
+		return
+	}
+}
+
+// AddGeneralField adds a general (OLE object) field to the structure.
+// Values are stored in a FoxPro memo file (.FPT).
+func (f *Fields) AddGeneralField(name string) {
+	f.addBinaryMemoField("AddGeneralField", name, 'G')
+}
+
+// AddPictureField adds a picture field to the structure.
+// Values are stored in a FoxPro memo file (.FPT).
+func (f *Fields) AddPictureField(name string) {
+	f.addBinaryMemoField("AddPictureField", name, 'P')
+}
+
+// AddBlobField adds a blob field to the structure.
+// Values are stored in a Visual FoxPro memo file (.FPT).
+func (f *Fields) AddBlobField(name string) {
+	f.addBinaryMemoField("AddBlobField", name, 'W')
+}
+
+func (f *Fields) addBinaryMemoField(fn, name string, typ byte) {
+	if f.err != nil {
+		return
+	}
+	item, err := newBinaryMemoField(name, typ)
+	if err != nil {
+		f.err = fmt.Errorf("%s: %w", fn, err)
+		return
+	}
+	if err := f.addItem(item); err != nil {
+		f.err = fmt.Errorf("%s: %w", fn, err)
+		return
+	}
+}
+
 // FieldInfo returns field information by index.
 func (f *Fields) FieldInfo(index int) (name, typ string, length, dec int) {
 	if f.err != nil {
@@ -133,19 +361,140 @@ func (f *Fields) FieldInfo(index int) (name, typ string, length, dec int) {
 	return
 }
 
-func (f *Fields) write(w io.Writer) error {
+// FieldFlags returns the Visual FoxPro field flags by index.
+func (f *Fields) FieldFlags(index int) FieldFlags {
+	if f.err != nil {
+		return 0
+	}
+	if err := f.checkFieldIndex(index); err != nil {
+		f.err = fmt.Errorf("FieldFlags: %w", err)
+		return 0
+	}
+	return f.items[index].flags()
+}
+
+// SetFieldFlags sets the Visual FoxPro field flags by index.
+// Only FlagSystem, FlagNullable and FlagBinary can be set;
+// FlagBinary is valid for Character and Memo fields.
+func (f *Fields) SetFieldFlags(index int, flags FieldFlags) {
+	if f.err != nil {
+		return
+	}
+	if err := f.checkFieldIndex(index); err != nil {
+		f.err = fmt.Errorf("SetFieldFlags: %w", err)
+		return
+	}
+	if flags&^(FlagSystem|FlagNullable|FlagBinary) != 0 {
+		f.err = fmt.Errorf("SetFieldFlags: unsupported field flags %#x", byte(flags))
+		return
+	}
+	item := f.items[index]
+	if flags&FlagBinary != 0 && item.Type != 'C' && item.Type != 'M' {
+		f.err = fmt.Errorf("SetFieldFlags: field type %q, want 'C', 'M'", item.Type)
+		return
+	}
+	item.Flags = item.Flags&^byte(FlagSystem|FlagNullable|FlagBinary) | byte(flags)
+	f.layoutNullFlags()
+}
+
+func (f *Fields) hasMemo() bool {
+	for _, item := range f.items {
+		if item.isMemo() {
+			return true
+		}
+	}
+	return false
+}
+
+// copyMemo copies the memo data of the record from src to dst
+// and sets the new memo blocks in recordBuf.
+// The blocks map holds the new blocks of the blocks already copied,
+// so a block shared by several records is copied once.
+func (f *Fields) copyMemo(recordBuf []byte, src *memoReader, dst *memoWriter, blocks map[uint32]uint32) error {
+	for _, item := range f.items {
+		if !item.isMemo() {
+			continue
+		}
+		block, err := item.memoBlock(recordBuf)
+		if err != nil {
+			return fmt.Errorf("field %q: %w", item.name(), err)
+		}
+		if newBlock, ok := blocks[block]; ok {
+			item.setMemoBlock(recordBuf, newBlock)
+			continue
+		}
+		data, err := item.memoBytesFieldValue(recordBuf, src)
+		if err != nil {
+			return fmt.Errorf("field %q: %w", item.name(), err)
+		}
+		if err := item.setMemoBytesFieldValue(recordBuf, data, dst); err != nil {
+			return fmt.Errorf("field %q: %w", item.name(), err)
+		}
+		if blocks[block], err = item.memoBlock(recordBuf); err != nil {
+			return fmt.Errorf("field %q: %w", item.name(), err)
+		}
+	}
+	return nil
+}
+
+// setMemoLen sets the length of memo fields and
+// recalculates the field offsets.
+func (f *Fields) setMemoLen(length int) {
+	for _, item := range f.items {
+		if item.isMemo() {
+			item.Len = byte(length)
+		}
+	}
+	f.updateOffsets()
+}
+
+func (f *Fields) hasVarLength() bool {
+	for _, item := range f.items {
+		if item.isVarLength() {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Fields) hasAutoIncrement() bool {
 	for _, item := range f.items {
-		if err := item.write(w); err != nil {
+		if item.isAutoIncrement() {
+			return true
+		}
+	}
+	return false
+}
+
+// setAutoIncrementValues assigns the next values to the autoincrement fields.
+func (f *Fields) setAutoIncrementValues(recordBuf []byte) error {
+	for i, item := range f.items {
+		if !item.isAutoIncrement() {
+			continue
+		}
+		if err := item.setAutoIncrementValue(recordBuf); err != nil {
+			return err
+		}
+		if err := f.setNotNull(i, recordBuf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (f *Fields) write(w io.Writer, version Version) error {
+	for _, item := range f.items {
+		if err := item.write(w, version); err != nil {
 			return err
 		}
 	}
 	return nil
 }
 
-func (f *Fields) read(r io.Reader, count int) error {
+func (f *Fields) read(r io.Reader, count int, version Version) error {
 	for i := 0; i < count; i++ {
 		item := &field{}
-		if err := item.read(r); err != nil {
+		if err := item.read(r, version); err != nil {
 			return err
 		}
 		f.addItem(item)
@@ -153,6 +502,53 @@ func (f *Fields) read(r io.Reader, count int) error {
 	return nil
 }
 
+func (f *Fields) readLevel7(r *bufio.Reader) error {
+	for {
+		b, err := r.Peek(1)
+		if err != nil {
+			return err
+		}
+		if b[0] == headerEnd {
+			return nil
+		}
+		item := &field{}
+		if err := item.readLevel7(r); err != nil {
+			return err
+		}
+		f.addItem(item)
+	}
+}
+
+// setLevel7 prepares the fields to be written in a dBase 7 file
+// if level7 is true, or in a file of an earlier version otherwise.
+// The Visual FoxPro types are replaced by the dBase 7 types.
+func (f *Fields) setLevel7(level7 bool) error {
+	for _, item := range f.items {
+		if level7 && !item.level7 {
+			switch {
+			case item.isAutoIncrement():
+				if item.AutoIncStep != 1 {
+					return fmt.Errorf("field %q autoincrement step %d, want 1", item.name(), item.AutoIncStep)
+				}
+				item.Type = '+'
+				item.Flags = 0
+				item.AutoIncStep = 0
+			case item.Type == 'T':
+				item.Type = '@'
+			case item.Type == 'B':
+				item.Type = 'O'
+			case item.Type == 'W':
+				item.Type = 'B'
+			}
+		}
+		if !level7 && item.level7 && item.Type == 'B' {
+			return fmt.Errorf("field type %q not supported", item.Type)
+		}
+		item.level7 = level7
+	}
+	return nil
+}
+
 func (f *Fields) checkFieldIndex(index int) error {
 	if index < 0 || index >= f.Count() {
 		return fmt.Errorf("field index out of range [%d] with field count %d", index, f.Count())
@@ -160,13 +556,70 @@ func (f *Fields) checkFieldIndex(index int) error {
 	return nil
 }
 
+// fieldError returns err with the field context.
+// The error of an invalid field index is returned unchanged.
+func (f *Fields) fieldError(index int, err error) error {
+	if f.checkFieldIndex(index) != nil {
+		return err
+	}
+	item := f.items[index]
+	return &FieldError{Index: index, Name: item.name(), Type: item.Type, Err: err}
+}
+
 // Get value
 
-func (f *Fields) stringFieldValue(index int, recordBuf []byte, decoder *encoding.Decoder) (string, error) {
+func (f *Fields) stringFieldValue(index int, recordBuf []byte, decoder *encoding.Decoder, memo *memoReader) (string, error) {
 	if err := f.checkFieldIndex(index); err != nil {
 		return "", err
 	}
-	return f.items[index].stringFieldValue(recordBuf, decoder)
+	item := f.items[index]
+	if item.isMemo() {
+		return item.memoFieldValue(recordBuf, memo, decoder)
+	}
+	if item.Type == 'V' {
+		buf, err := f.varFieldValue(index, recordBuf)
+		if err != nil {
+			return "", err
+		}
+		s := string(buf)
+		if decoder != nil && !item.isBinary() && !isASCII(s) {
+			return decoder.String(s)
+		}
+		return s, nil
+	}
+	return item.stringFieldValue(recordBuf, decoder)
+}
+
+func (f *Fields) currencyFieldValue(index int, recordBuf []byte) (int64, error) {
+	if err := f.checkFieldIndex(index); err != nil {
+		return 0, err
+	}
+	return f.items[index].currencyFieldValue(recordBuf)
+}
+
+func (f *Fields) dateTimeFieldValue(index int, recordBuf []byte) (time.Time, error) {
+	if err := f.checkFieldIndex(index); err != nil {
+		return time.Time{}, err
+	}
+	return f.items[index].dateTimeFieldValue(recordBuf)
+}
+
+func (f *Fields) bytesFieldValue(index int, recordBuf []byte, memo *memoReader) ([]byte, error) {
+	if err := f.checkFieldIndex(index); err != nil {
+		return nil, err
+	}
+	item := f.items[index]
+	if item.isVarLength() {
+		buf, err := f.varFieldValue(index, recordBuf)
+		if err != nil {
+			return nil, err
+		}
+		return append([]byte(nil), buf...), nil
+	}
+	if !item.isMemo() {
+		return nil, fmt.Errorf("%w: field type %q, want memo type, 'V', 'Q'", ErrTypeMismatch, item.Type)
+	}
+	return item.memoBytesFieldValue(recordBuf, memo)
 }
 
 func (f *Fields) boolFieldValue(index int, recordBuf []byte) (bool, error) {
@@ -199,17 +652,93 @@ func (f *Fields) floatFieldValue(index int, recordBuf []byte) (float64, error) {
 
 // Set value
 
-func (f *Fields) setStringFieldValue(index int, recordBuf []byte, value string, encoder *encoding.Encoder) error {
+func (f *Fields) clear(recordBuf []byte) {
+	recordBuf[0] = ' '
+	for _, item := range f.items {
+		item.clear(recordBuf)
+	}
+	if f.nullFlags == nil {
+		return
+	}
+	for i, item := range f.items {
+		if item.isVarLength() {
+			f.setVarFieldValue(i, recordBuf, nil)
+		}
+		if bit := f.nullBits[i]; bit >= 0 {
+			f.setNullFlagsBit(recordBuf, bit, true)
+		}
+	}
+}
+
+func (f *Fields) setStringFieldValue(index int, recordBuf []byte, value string, encoder *encoding.Encoder, memo *memoWriter) error {
 	if err := f.checkFieldIndex(index); err != nil {
 		return err
 	}
+	if err := f.setNotNull(index, recordBuf); err != nil {
+		return err
+	}
+	item := f.items[index]
+	if item.isMemo() {
+		return item.setMemoFieldValue(recordBuf, value, memo, encoder)
+	}
+	if item.Type == 'V' {
+		var err error
+		s := value
+		if encoder != nil && !item.isBinary() && !isASCII(s) {
+			s, err = encoder.String(s)
+			if err != nil {
+				return err
+			}
+		}
+		return f.setVarFieldValue(index, recordBuf, []byte(s))
+	}
 	return f.items[index].setStringFieldValue(recordBuf, value, encoder)
 }
 
+func (f *Fields) setCurrencyFieldValue(index int, recordBuf []byte, value int64) error {
+	if err := f.checkFieldIndex(index); err != nil {
+		return err
+	}
+	if err := f.setNotNull(index, recordBuf); err != nil {
+		return err
+	}
+	return f.items[index].setCurrencyFieldValue(recordBuf, value)
+}
+
+func (f *Fields) setDateTimeFieldValue(index int, recordBuf []byte, value time.Time) error {
+	if err := f.checkFieldIndex(index); err != nil {
+		return err
+	}
+	if err := f.setNotNull(index, recordBuf); err != nil {
+		return err
+	}
+	return f.items[index].setDateTimeFieldValue(recordBuf, value)
+}
+
+func (f *Fields) setBytesFieldValue(index int, recordBuf []byte, value []byte, memo *memoWriter) error {
+	if err := f.checkFieldIndex(index); err != nil {
+		return err
+	}
+	if err := f.setNotNull(index, recordBuf); err != nil {
+		return err
+	}
+	item := f.items[index]
+	if item.isVarLength() {
+		return f.setVarFieldValue(index, recordBuf, value)
+	}
+	if !item.isMemo() {
+		return fmt.Errorf("%w: field type %q, want memo type, 'V', 'Q'", ErrTypeMismatch, item.Type)
+	}
+	return item.setMemoBytesFieldValue(recordBuf, value, memo)
+}
+
 func (f *Fields) setBoolFieldValue(index int, recordBuf []byte, value bool) error {
 	if err := f.checkFieldIndex(index); err != nil {
 		return err
 	}
+	if err := f.setNotNull(index, recordBuf); err != nil {
+		return err
+	}
 	return f.items[index].setBoolFieldValue(recordBuf, value)
 }
 
@@ -217,6 +746,9 @@ func (f *Fields) setDateFieldValue(index int, recordBuf []byte, value time.Time)
 	if err := f.checkFieldIndex(index); err != nil {
 		return err
 	}
+	if err := f.setNotNull(index, recordBuf); err != nil {
+		return err
+	}
 	return f.items[index].setDateFieldValue(recordBuf, value)
 }
 
@@ -224,6 +756,9 @@ func (f *Fields) setIntFieldValue(index int, recordBuf []byte, value int64) erro
 	if err := f.checkFieldIndex(index); err != nil {
 		return err
 	}
+	if err := f.setNotNull(index, recordBuf); err != nil {
+		return err
+	}
 	return f.items[index].setIntFieldValue(recordBuf, value)
 }
 
@@ -231,5 +766,8 @@ func (f *Fields) setFloatFieldValue(index int, recordBuf []byte, value float64)
 	if err := f.checkFieldIndex(index); err != nil {
 		return err
 	}
+	if err := f.setNotNull(index, recordBuf); err != nil {
+		return err
+	}
 	return f.items[index].setFloatFieldValue(recordBuf, value)
 }
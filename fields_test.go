@@ -57,7 +57,7 @@ func Test_Fields_write(t *testing.T) {
 	f.AddDateField("date")
 
 	buf := bytes.NewBuffer(nil)
-	err := f.write(buf)
+	err := f.write(buf, DBase3)
 
 	if err != nil {
 		t.Errorf("Fields.write(): %v", err)
@@ -76,7 +76,7 @@ func Test_Fields_read(t *testing.T) {
 	r := bytes.NewReader(b)
 
 	f := NewFields()
-	err := f.read(r, 1)
+	err := f.read(r, 1, DBase3)
 
 	if err != nil {
 		t.Errorf("Fields.read(): %v", err)
@@ -86,6 +86,38 @@ func Test_Fields_read(t *testing.T) {
 	}
 }
 
+func Test_Fields_read_reserved(t *testing.T) {
+	b := make([]byte, fieldSize)
+	copy(b[:], "NAME")
+	b[11] = 'C'
+	b[16] = 14
+	// Garbage in the bytes reserved by dBase
+	b[18] = byte(FlagNullable)
+	b[19] = 0xFF
+	b[23] = 0xFF
+
+	for _, tc := range []struct {
+		version Version
+		flags   byte
+	}{
+		{DBase3, 0},
+		{DBase4, 0},
+		{VisualFoxPro, byte(FlagNullable)},
+	} {
+		f := NewFields()
+		if err := f.read(bytes.NewReader(b), 1, tc.version); err != nil {
+			t.Fatalf("Fields.read(%v): %v", tc.version, err)
+		}
+		item := f.items[0]
+		if item.Flags != tc.flags {
+			t.Errorf("Fields.read(%v): Flags: want: %#x, got: %#x", tc.version, tc.flags, item.Flags)
+		}
+		if tc.version != VisualFoxPro && (item.AutoIncNext != 0 || item.AutoIncStep != 0) {
+			t.Errorf("Fields.read(%v): autoincrement: want: 0 0, got: %v %v", tc.version, item.AutoIncNext, item.AutoIncStep)
+		}
+	}
+}
+
 func Test_Fields_set_record_buffer(t *testing.T) {
 	f := NewFields()
 	f.AddCharacterField("name", 6)
@@ -94,7 +126,7 @@ func Test_Fields_set_record_buffer(t *testing.T) {
 
 	buf := []byte(strings.Repeat(" ", f.recSize))
 
-	f.setStringFieldValue(0, buf, "Abc", nil)
+	f.setStringFieldValue(0, buf, "Abc", nil, nil)
 	f.setBoolFieldValue(1, buf, true)
 	f.setIntFieldValue(2, buf, 34)
 
@@ -114,7 +146,7 @@ func Test_Fields_get_field_values(t *testing.T) {
 
 	buf := []byte(" Abc   T  34")
 
-	name, _ := f.stringFieldValue(0, buf, nil)
+	name, _ := f.stringFieldValue(0, buf, nil, nil)
 	flag, _ := f.boolFieldValue(1, buf)
 	count, _ := f.intFieldValue(2, buf)
 
@@ -137,3 +169,62 @@ func Test_Fields_check_duplicate(t *testing.T) {
 		t.Errorf("Fields: add field duplicate: not error")
 	}
 }
+
+func Test_Fields_SetFieldFlags(t *testing.T) {
+	f := NewFields()
+	f.AddCharacterField("name", 6)
+	f.AddLogicalField("flag")
+
+	f.SetFieldFlags(0, FlagBinary)
+	if f.FieldFlags(0) != FlagBinary {
+		t.Errorf("Fields.FieldFlags(0): want: %#x, got: %#x", FlagBinary, f.FieldFlags(0))
+	}
+	f.SetFieldFlags(1, FlagBinary)
+	if f.err == nil {
+		t.Errorf("Fields.SetFieldFlags(1, FlagBinary): logical field: error required")
+	}
+}
+
+func Test_Fields_AddIntegerField(t *testing.T) {
+	f := NewFields()
+	f.AddIntegerField("id")
+	f.AddCharacterField("name", 6)
+
+	buf := make([]byte, f.recSize)
+	f.clear(buf)
+	f.setIntFieldValue(0, buf, 7)
+	f.setStringFieldValue(1, buf, "Abc", nil, nil)
+
+	want := " \x07\x00\x00\x00Abc   "
+	if string(buf) != want {
+		t.Errorf("Record buffer: want: %#v, got: %#v", want, string(buf))
+	}
+}
+
+func Test_Fields_FieldIndex(t *testing.T) {
+	f := NewFields()
+	f.AddCharacterField("name", 10)
+	f.AddDateField("date")
+
+	tests := []struct {
+		name  string
+		index int
+		ok    bool
+	}{
+		{name: "NAME", index: 0, ok: true},
+		{name: "date", index: 1, ok: true},
+		{name: "Date", index: 1, ok: true},
+		{name: "count", index: 0, ok: false},
+	}
+	for _, tc := range tests {
+		index, ok := f.FieldIndex(tc.name)
+		if index != tc.index || ok != tc.ok {
+			t.Errorf("Fields.FieldIndex(%q): want: %v %v, got: %v %v", tc.name, tc.index, tc.ok, index, ok)
+		}
+	}
+
+	f.AddCharacterField("Name", 5)
+	if f.Err() == nil {
+		t.Errorf("Fields.AddCharacterField('Name'): duplicate name: error required")
+	}
+}
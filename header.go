@@ -2,17 +2,22 @@ package dbf
 
 import (
 	"encoding/binary"
-	"fmt"
 	"io"
 	"time"
 )
 
 const (
-	dbfId     byte = 0x03
 	headerEnd byte = 0x0D
 
+	// Visual FoxPro table flags
+	flagMemo byte = 0x02
+
 	headerSize = 32
 	yearOffset = 1900
+
+	// dBase 7 header is followed by the language driver name
+	level7HeaderSize  = 68
+	languageDriverLen = 32
 )
 
 type header struct {
@@ -23,7 +28,8 @@ type header struct {
 	RecCount   uint32
 	DataOffset uint16
 	RecSize    uint16
-	Filler1    [17]byte
+	Filler1    [16]byte
+	Flags      byte
 	CP         byte
 	Filler2    [2]byte
 }
@@ -50,17 +56,45 @@ func (h *header) setModDate(d time.Time) {
 	h.ModDay = byte(d.Day())
 }
 
+// Version
+
+func (h *header) version() Version {
+	v, _ := versionById(h.Id)
+	return v
+}
+
 // Field count
 
-func (h *header) fieldCount() int {
+func (h *header) fieldCount(version Version) int {
 	if h.DataOffset == 0 {
 		return 0
 	}
-	return (int(h.DataOffset) - headerSize - 1) / fieldSize
+	return (int(h.DataOffset) - version.headerSize() - 1 - version.backlinkSize()) / version.fieldSize()
 }
 
-func (h *header) setFieldCount(count int) {
-	h.DataOffset = uint16(count*fieldSize + headerSize + 1)
+func (h *header) setFieldCount(count int, version Version) {
+	h.DataOffset = uint16(count*version.fieldSize() + version.headerSize() + 1 + version.backlinkSize())
+}
+
+// fileSize returns the size of the file with RecCount records
+// and the end of file marker.
+func (h *header) fileSize() int64 {
+	return int64(h.DataOffset) + int64(h.RecCount)*int64(h.RecSize) + 1
+}
+
+// isLevel7 reports whether the file with id 0x04 is a dBase 7 file.
+// next is the data following the header: the first field descriptor
+// of a dBase IV file or the language driver name of a dBase 7 file.
+func (h *header) isLevel7(next []byte) bool {
+	if h.Id != dBase4Id {
+		return false
+	}
+	// dBase IV header has no data after the field descriptors
+	if (int(h.DataOffset)-headerSize-1)%fieldSize != 0 {
+		return true
+	}
+	// Field type of dBase IV descriptor is never zero
+	return len(next) > 11 && next[11] == 0
 }
 
 // Read/write
@@ -69,8 +103,8 @@ func (h *header) read(reader io.Reader) error {
 	if err := binary.Read(reader, binary.LittleEndian, h); err != nil {
 		return err
 	}
-	if h.Id != dbfId {
-		return fmt.Errorf("not DBF file")
+	if _, ok := versionById(h.Id); !ok {
+		return ErrNotDBF
 	}
 	return nil
 }
@@ -26,8 +26,8 @@ func Test_newHeader(t *testing.T) {
 	if h.RecCount != 0 {
 		t.Errorf(tpl, "h.RecCount", 0, h.RecCount)
 	}
-	if h.fieldCount() != 0 {
-		t.Errorf(tpl, "h.fieldCount()", 0, h.fieldCount())
+	if h.fieldCount(DBase3) != 0 {
+		t.Errorf(tpl, "h.fieldCount(DBase3)", 0, h.fieldCount(DBase3))
 	}
 	if h.RecSize != 0 {
 		t.Errorf(tpl, "h.RecSize", 0, h.RecSize)
@@ -45,7 +45,7 @@ func Test_header_write(t *testing.T) {
 	h := newHeader()
 	h.RecCount = uint32(3)
 	h.RecSize = uint16(39)
-	h.setFieldCount(5)
+	h.setFieldCount(5, DBase3)
 	h.setModDate(time.Date(1930, 2, 20, 0, 0, 0, 0, time.UTC))
 	h.setCodePage(866)
 
@@ -72,8 +72,8 @@ func Test_header_read(t *testing.T) {
 	if h.RecCount != 3 {
 		t.Errorf(tpl, "h.RecCount", 3, h.RecCount)
 	}
-	if h.fieldCount() != 5 {
-		t.Errorf(tpl, "h.fieldCount()", 5, h.fieldCount())
+	if h.fieldCount(DBase3) != 5 {
+		t.Errorf(tpl, "h.fieldCount(DBase3)", 5, h.fieldCount(DBase3))
 	}
 	if h.RecSize != 39 {
 		t.Errorf(tpl, "h.RecSize", 39, h.RecSize)
@@ -110,3 +110,46 @@ func Test_header_setCodePage(t *testing.T) {
 		t.Errorf("header.setCodePage(866): h.codePage(): want: %v, got: %v", 866, h.codePage())
 	}
 }
+
+func Test_header_fieldCount_VisualFoxPro(t *testing.T) {
+	h := &header{Id: 0x30}
+	h.setFieldCount(3, VisualFoxPro)
+
+	if h.DataOffset != 32+3*32+1+263 {
+		t.Errorf("header.setFieldCount(3): h.DataOffset: want: %v, got: %v", 32+3*32+1+263, h.DataOffset)
+	}
+	if h.fieldCount(VisualFoxPro) != 3 {
+		t.Errorf("header.fieldCount(): want: %v, got: %v", 3, h.fieldCount(VisualFoxPro))
+	}
+}
+
+func Test_header_version(t *testing.T) {
+	h := &header{Id: 0xF5}
+
+	if h.version() != FoxPro {
+		t.Errorf("header.version(): want: %v, got: %v", FoxPro, h.version())
+	}
+}
+
+func Test_header_isLevel7(t *testing.T) {
+	descriptor := []byte{'N', 'A', 'M', 'E', 0, 0, 0, 0, 0, 0, 0, 'C'}
+	langDriver := []byte{'D', 'B', 'W', 'I', 'N', 'U', 'S', '0', 0, 0, 0, 0}
+
+	tests := []struct {
+		id         byte
+		dataOffset uint16
+		next       []byte
+		want       bool
+	}{
+		{id: 0x04, dataOffset: 32 + 2*32 + 1, next: descriptor, want: false},
+		{id: 0x04, dataOffset: 68 + 2*48 + 1, next: langDriver, want: true},
+		{id: 0x04, dataOffset: 32 + 4*32 + 1, next: langDriver, want: true},
+		{id: 0x03, dataOffset: 68 + 2*48 + 1, next: langDriver, want: false},
+	}
+	for _, tc := range tests {
+		h := &header{Id: tc.id, DataOffset: tc.dataOffset}
+		if got := h.isLevel7(tc.next); got != tc.want {
+			t.Errorf("header.isLevel7(): id %#x, data offset %d: want: %v, got: %v", tc.id, tc.dataOffset, tc.want, got)
+		}
+	}
+}
@@ -0,0 +1,291 @@
+package dbf
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+const (
+	memoBlockSize            = 512
+	memoHeaderSize           = 512
+	memoEnd             byte = 0x1A
+	foxProMemoBlockSize      = 64
+
+	// dBase IV memo block header
+	dBase4MemoMarker     uint32 = 0x0008FFFF
+	dBase4MemoHeaderSize        = 8
+
+	// FoxPro memo block types
+	memoPicture uint32 = 0
+	memoText    uint32 = 1
+)
+
+// Memo reader
+
+type memoReader struct {
+	ra        io.ReaderAt
+	version   Version
+	blockSize int
+}
+
+func newMemoReader(ra io.ReaderAt, version Version) (*memoReader, error) {
+	m := &memoReader{
+		ra:        ra,
+		version:   version,
+		blockSize: memoBlockSize,
+	}
+	buf := make([]byte, 8)
+	if _, err := ra.ReadAt(buf, 0); err != nil {
+		return nil, err
+	}
+	switch version {
+	case FoxPro, VisualFoxPro:
+		m.blockSize = int(binary.BigEndian.Uint16(buf[6:]))
+	case DBase4, DBase7:
+		buf = make([]byte, 2)
+		if _, err := ra.ReadAt(buf, 20); err != nil {
+			return nil, err
+		}
+		m.blockSize = int(binary.LittleEndian.Uint16(buf))
+	}
+	if m.blockSize == 0 {
+		return nil, fmt.Errorf("invalid memo block size %d", m.blockSize)
+	}
+	return m, nil
+}
+
+func (m *memoReader) read(block uint32) ([]byte, error) {
+	if block == 0 {
+		return nil, fmt.Errorf("invalid memo block %d", block)
+	}
+	off := int64(block) * int64(m.blockSize)
+	switch m.version {
+	case FoxPro, VisualFoxPro:
+		return m.readFoxPro(off)
+	case DBase4, DBase7:
+		return m.readDBase4(off)
+	}
+	return m.readDBase3(off)
+}
+
+func (m *memoReader) readDBase3(off int64) ([]byte, error) {
+	var data []byte
+	buf := make([]byte, m.blockSize)
+	for {
+		n, err := m.ra.ReadAt(buf, off)
+		if i := bytes.IndexByte(buf[:n], memoEnd); i >= 0 {
+			return append(data, buf[:i]...), nil
+		}
+		data = append(data, buf[:n]...)
+		if err == io.EOF {
+			return data, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		off += int64(n)
+	}
+}
+
+func (m *memoReader) readFoxPro(off int64) ([]byte, error) {
+	buf := make([]byte, 8)
+	if _, err := m.ra.ReadAt(buf, off); err != nil {
+		return nil, err
+	}
+	size := binary.BigEndian.Uint32(buf[4:])
+	return m.readData(off+8, size)
+}
+
+func (m *memoReader) readDBase4(off int64) ([]byte, error) {
+	buf := make([]byte, dBase4MemoHeaderSize)
+	if _, err := m.ra.ReadAt(buf, off); err != nil {
+		return nil, err
+	}
+	// Blocks written by dBase III are terminated by end marker
+	if binary.LittleEndian.Uint32(buf) != dBase4MemoMarker {
+		return m.readDBase3(off)
+	}
+	size := binary.LittleEndian.Uint32(buf[4:])
+	if size < dBase4MemoHeaderSize {
+		return nil, fmt.Errorf("invalid memo length %d", size)
+	}
+	return m.readData(off+dBase4MemoHeaderSize, size-dBase4MemoHeaderSize)
+}
+
+// readData reads size bytes at off. The buffer grows with the data read,
+// so a corrupt length does not allocate more than the memo file size.
+func (m *memoReader) readData(off int64, size uint32) ([]byte, error) {
+	data, err := io.ReadAll(io.NewSectionReader(m.ra, off, int64(size)))
+	if err != nil {
+		return nil, err
+	}
+	if len(data) != int(size) {
+		return nil, io.ErrUnexpectedEOF
+	}
+	return data, nil
+}
+
+// Memo writer
+
+type memoWriter struct {
+	ws        io.WriteSeeker
+	writer    *bufio.Writer
+	version   Version
+	blockSize int
+	nextBlock uint32
+}
+
+func newMemoWriter(ws io.WriteSeeker, version Version, blockSize int) (*memoWriter, error) {
+	m := &memoWriter{
+		ws:        ws,
+		writer:    bufio.NewWriter(ws),
+		version:   version,
+		blockSize: memoBlockSize,
+		nextBlock: 1,
+	}
+	switch version {
+	case FoxPro, VisualFoxPro:
+		if blockSize == 0 {
+			blockSize = foxProMemoBlockSize
+		}
+		if blockSize < 0 || blockSize > 0xFFFF {
+			return nil, fmt.Errorf("memo block size %d, want 0 < size <= %d", blockSize, 0xFFFF)
+		}
+		m.blockSize = blockSize
+		m.nextBlock = uint32((memoHeaderSize + blockSize - 1) / blockSize)
+	case DBase4, DBase7:
+		if blockSize == 0 {
+			blockSize = memoBlockSize
+		}
+		if blockSize < 0 || blockSize > 0xFFFF || blockSize%memoBlockSize != 0 {
+			return nil, fmt.Errorf("memo block size %d, want multiple of %d", blockSize, memoBlockSize)
+		}
+		m.blockSize = blockSize
+	}
+	if err := m.writeHeader(m.writer); err != nil {
+		return nil, err
+	}
+	// Pad header to the first block
+	pad := int(m.nextBlock)*m.blockSize - memoHeaderSize
+	if _, err := m.writer.Write(make([]byte, pad)); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+// openMemoWriter returns a memo writer which appends data
+// to the existing memo file.
+func openMemoWriter(rws io.ReadWriteSeeker, version Version) (*memoWriter, error) {
+	buf := make([]byte, 22)
+	if _, err := rws.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	if _, err := io.ReadFull(rws, buf); err != nil {
+		return nil, err
+	}
+	m := &memoWriter{
+		ws:        rws,
+		version:   version,
+		blockSize: memoBlockSize,
+	}
+	switch version {
+	case FoxPro, VisualFoxPro:
+		m.nextBlock = binary.BigEndian.Uint32(buf)
+		m.blockSize = int(binary.BigEndian.Uint16(buf[6:]))
+	case DBase4, DBase7:
+		m.nextBlock = binary.LittleEndian.Uint32(buf)
+		m.blockSize = int(binary.LittleEndian.Uint16(buf[20:]))
+	default:
+		m.nextBlock = binary.LittleEndian.Uint32(buf)
+	}
+	if m.blockSize == 0 || m.nextBlock == 0 {
+		return nil, fmt.Errorf("invalid memo header: block size %d, next block %d", m.blockSize, m.nextBlock)
+	}
+	if _, err := rws.Seek(int64(m.nextBlock)*int64(m.blockSize), io.SeekStart); err != nil {
+		return nil, err
+	}
+	m.writer = bufio.NewWriter(rws)
+	return m, nil
+}
+
+func (m *memoWriter) writeHeader(w io.Writer) error {
+	buf := make([]byte, memoHeaderSize)
+	switch m.version {
+	case FoxPro, VisualFoxPro:
+		binary.BigEndian.PutUint32(buf, m.nextBlock)
+		binary.BigEndian.PutUint16(buf[6:], uint16(m.blockSize))
+	case DBase4, DBase7:
+		binary.LittleEndian.PutUint32(buf, m.nextBlock)
+		binary.LittleEndian.PutUint16(buf[20:], uint16(m.blockSize))
+	default:
+		binary.LittleEndian.PutUint32(buf, m.nextBlock)
+	}
+	_, err := w.Write(buf)
+	return err
+}
+
+// writeNextBlock writes the next free block field of the header.
+// The other header bytes of an opened memo file are kept.
+func (m *memoWriter) writeNextBlock(w io.Writer) error {
+	buf := make([]byte, 4)
+	switch m.version {
+	case FoxPro, VisualFoxPro:
+		binary.BigEndian.PutUint32(buf, m.nextBlock)
+	default:
+		binary.LittleEndian.PutUint32(buf, m.nextBlock)
+	}
+	_, err := w.Write(buf)
+	return err
+}
+
+func (m *memoWriter) write(data []byte, typ uint32) (uint32, error) {
+	var buf []byte
+	switch m.version {
+	case FoxPro, VisualFoxPro:
+		buf = make([]byte, 8, 8+len(data))
+		binary.BigEndian.PutUint32(buf, typ)
+		binary.BigEndian.PutUint32(buf[4:], uint32(len(data)))
+		buf = append(buf, data...)
+	case DBase4, DBase7:
+		buf = make([]byte, dBase4MemoHeaderSize, dBase4MemoHeaderSize+len(data))
+		binary.LittleEndian.PutUint32(buf, dBase4MemoMarker)
+		binary.LittleEndian.PutUint32(buf[4:], uint32(dBase4MemoHeaderSize+len(data)))
+		buf = append(buf, data...)
+	default:
+		if bytes.IndexByte(data, memoEnd) >= 0 {
+			return 0, fmt.Errorf("memo data contains end marker %#x", memoEnd)
+		}
+		buf = make([]byte, 0, len(data)+2)
+		buf = append(buf, data...)
+		buf = append(buf, memoEnd, memoEnd)
+	}
+	count := (len(buf) + m.blockSize - 1) / m.blockSize
+	pad := count*m.blockSize - len(buf)
+	if _, err := m.writer.Write(buf); err != nil {
+		return 0, err
+	}
+	if _, err := m.writer.Write(make([]byte, pad)); err != nil {
+		return 0, err
+	}
+	block := m.nextBlock
+	m.nextBlock += uint32(count)
+	return block, nil
+}
+
+func (m *memoWriter) flush() error {
+	if err := m.writer.Flush(); err != nil {
+		return err
+	}
+	// modify next free block in header
+	if _, err := m.ws.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if err := m.writeNextBlock(m.ws); err != nil {
+		return err
+	}
+	_, err := m.ws.Seek(0, io.SeekEnd)
+	return err
+}
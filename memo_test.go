@@ -0,0 +1,452 @@
+package dbf
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+)
+
+// memFile is an in-memory file for tests.
+type memFile struct {
+	buf []byte
+	pos int64
+}
+
+func (m *memFile) Write(p []byte) (int, error) {
+	end := int(m.pos) + len(p)
+	if end > len(m.buf) {
+		m.buf = append(m.buf, make([]byte, end-len(m.buf))...)
+	}
+	copy(m.buf[m.pos:], p)
+	m.pos = int64(end)
+	return len(p), nil
+}
+
+func (m *memFile) Read(p []byte) (int, error) {
+	if m.pos >= int64(len(m.buf)) {
+		return 0, io.EOF
+	}
+	n := copy(p, m.buf[m.pos:])
+	m.pos += int64(n)
+	return n, nil
+}
+
+func (m *memFile) ReadAt(p []byte, off int64) (int, error) {
+	if off >= int64(len(m.buf)) {
+		return 0, io.EOF
+	}
+	n := copy(p, m.buf[off:])
+	if n < len(p) {
+		return n, io.EOF
+	}
+	return n, nil
+}
+
+func (m *memFile) Seek(offset int64, whence int) (int64, error) {
+	switch whence {
+	case io.SeekStart:
+	case io.SeekCurrent:
+		offset += m.pos
+	case io.SeekEnd:
+		offset += int64(len(m.buf))
+	}
+	if offset < 0 {
+		return 0, errors.New("negative position")
+	}
+	m.pos = offset
+	return offset, nil
+}
+
+func Test_memoWriter_write(t *testing.T) {
+	f := &memFile{}
+	m, err := newMemoWriter(f, DBase3, 0)
+	if err != nil {
+		t.Fatalf("newMemoWriter(): %v", err)
+	}
+
+	tests := []struct {
+		data []byte
+		want uint32
+	}{
+		{data: []byte("Abc"), want: 1},
+		{data: bytes.Repeat([]byte("x"), 511), want: 2},
+		{data: []byte("Def"), want: 4},
+	}
+	for _, tc := range tests {
+		got, err := m.write(tc.data, memoText)
+		if err != nil {
+			t.Errorf("memoWriter.write(): %v", err)
+		}
+		if got != tc.want {
+			t.Errorf("memoWriter.write(): block: want: %v, got: %v", tc.want, got)
+		}
+	}
+	if err := m.flush(); err != nil {
+		t.Errorf("memoWriter.flush(): %v", err)
+	}
+	if len(f.buf) != 5*memoBlockSize {
+		t.Errorf("memo file len: want: %v, got: %v", 5*memoBlockSize, len(f.buf))
+	}
+	if next := binary.LittleEndian.Uint32(f.buf); next != 5 {
+		t.Errorf("memo file next block: want: %v, got: %v", 5, next)
+	}
+}
+
+func Test_memoReader_read(t *testing.T) {
+	f := &memFile{}
+	m, _ := newMemoWriter(f, DBase3, 0)
+	long := strings.Repeat("z", 1000)
+	m.write([]byte("Abc"), memoText)
+	m.write([]byte(long), memoText)
+	m.flush()
+
+	r, err := newMemoReader(f, DBase3)
+	if err != nil {
+		t.Fatalf("newMemoReader(): %v", err)
+	}
+
+	tests := []struct {
+		block uint32
+		want  string
+	}{
+		{block: 1, want: "Abc"},
+		{block: 2, want: long},
+	}
+	for _, tc := range tests {
+		got, err := r.read(tc.block)
+		if err != nil {
+			t.Errorf("memoReader.read(%d): %v", tc.block, err)
+		}
+		if string(got) != tc.want {
+			t.Errorf("memoReader.read(%d): want: %#v, got: %#v", tc.block, tc.want, string(got))
+		}
+	}
+}
+
+func Test_Writer_Reader_memo(t *testing.T) {
+	fields := NewFields()
+	fields.AddCharacterField("NAME", 10)
+	fields.AddMemoField("NOTE")
+
+	dbf := &memFile{}
+	dbt := &memFile{}
+
+	w, err := NewWriter(dbf, fields, 866)
+	if err != nil {
+		t.Fatalf("NewWriter(): %v", err)
+	}
+	w.SetMemoWriter(dbt)
+
+	records := []struct {
+		name string
+		note string
+	}{
+		{"Abc", "First memo"},
+		{"Def", ""},
+		{"Мышь", "Вторая заметка"},
+	}
+	for _, rec := range records {
+		w.SetStringFieldValue(0, rec.name)
+		w.SetStringFieldValue(1, rec.note)
+		w.Write()
+	}
+	w.Flush()
+	if w.Err() != nil {
+		t.Fatalf("Writer: %v", w.Err())
+	}
+	if dbf.buf[0] != dbfMemoId {
+		t.Errorf("header Id: want: %#x, got: %#x", dbfMemoId, dbf.buf[0])
+	}
+
+	dbf.Seek(0, io.SeekStart)
+	r, err := NewReader(dbf)
+	if err != nil {
+		t.Fatalf("NewReader(): %v", err)
+	}
+	r.SetMemoReader(dbt)
+
+	i := 0
+	for r.Read() {
+		want := records[i]
+		i++
+		if got := r.StringFieldValue(1); got != want.note {
+			t.Errorf("r.StringFieldValue(1): want: %#v, got: %#v", want.note, got)
+		}
+	}
+	if r.Err() != nil {
+		t.Errorf("Reader: %v", r.Err())
+	}
+	if i != len(records) {
+		t.Errorf("records read: want: %v, got: %v", len(records), i)
+	}
+}
+
+func Test_Writer_memo_not_set(t *testing.T) {
+	fields := NewFields()
+	fields.AddMemoField("NOTE")
+
+	w, _ := NewWriter(&memFile{}, fields, 0)
+	w.SetStringFieldValue(0, "Abc")
+
+	if w.Err() == nil {
+		t.Errorf("SetStringFieldValue(): memo file not set: error required")
+	}
+}
+
+func Test_memoWriter_write_FoxPro(t *testing.T) {
+	f := &memFile{}
+	m, err := newMemoWriter(f, FoxPro, 64)
+	if err != nil {
+		t.Fatalf("newMemoWriter(): %v", err)
+	}
+
+	tests := []struct {
+		data []byte
+		want uint32
+	}{
+		{data: []byte("Abc"), want: 8},
+		{data: bytes.Repeat([]byte("x"), 57), want: 9},
+		{data: []byte{0x1A, 0, 0xFF}, want: 11},
+	}
+	for _, tc := range tests {
+		got, err := m.write(tc.data, memoText)
+		if err != nil {
+			t.Errorf("memoWriter.write(): %v", err)
+		}
+		if got != tc.want {
+			t.Errorf("memoWriter.write(): block: want: %v, got: %v", tc.want, got)
+		}
+	}
+	if err := m.flush(); err != nil {
+		t.Errorf("memoWriter.flush(): %v", err)
+	}
+	if len(f.buf) != 12*64 {
+		t.Errorf("memo file len: want: %v, got: %v", 12*64, len(f.buf))
+	}
+	if next := binary.BigEndian.Uint32(f.buf); next != 12 {
+		t.Errorf("memo file next block: want: %v, got: %v", 12, next)
+	}
+	if size := binary.BigEndian.Uint16(f.buf[6:]); size != 64 {
+		t.Errorf("memo file block size: want: %v, got: %v", 64, size)
+	}
+}
+
+func Test_memoReader_read_FoxPro(t *testing.T) {
+	for _, blockSize := range []int{1, 32, 64, 1024} {
+		f := &memFile{}
+		m, _ := newMemoWriter(f, FoxPro, blockSize)
+		bin := []byte{0x1A, 0, 0xFF, ' '}
+		b1, _ := m.write([]byte("Abc"), memoText)
+		b2, _ := m.write(bin, memoPicture)
+		m.flush()
+
+		r, err := newMemoReader(f, FoxPro)
+		if err != nil {
+			t.Fatalf("newMemoReader(): %v", err)
+		}
+		if r.blockSize != blockSize {
+			t.Errorf("memoReader.blockSize: want: %v, got: %v", blockSize, r.blockSize)
+		}
+		if got, _ := r.read(b1); string(got) != "Abc" {
+			t.Errorf("memoReader.read(%d): want: %#v, got: %#v", b1, "Abc", string(got))
+		}
+		if got, _ := r.read(b2); !bytes.Equal(got, bin) {
+			t.Errorf("memoReader.read(%d): want: %#v, got: %#v", b2, bin, got)
+		}
+	}
+}
+
+func Test_memoReader_read_FoxPro_invalid_length(t *testing.T) {
+	f := &memFile{}
+	m, _ := newMemoWriter(f, FoxPro, 0)
+	block, _ := m.write([]byte("Abc"), memoText)
+	m.flush()
+	// Corrupt length of the memo data
+	off := int(block) * foxProMemoBlockSize
+	binary.BigEndian.PutUint32(f.buf[off+4:], 0xFFFFFFFF)
+
+	r, err := newMemoReader(f, FoxPro)
+	if err != nil {
+		t.Fatalf("newMemoReader(): %v", err)
+	}
+	if _, err := r.read(block); err != io.ErrUnexpectedEOF {
+		t.Errorf("memoReader.read(%d): want: %v, got: %v", block, io.ErrUnexpectedEOF, err)
+	}
+}
+
+func Test_Writer_Reader_memo_FoxPro(t *testing.T) {
+	fields := NewFields()
+	fields.AddMemoField("NOTE")
+	fields.AddPictureField("PICT")
+
+	dbf := &memFile{}
+	fpt := &memFile{}
+
+	w, err := NewWriterOptions(dbf, fields, WriterOptions{CodePage: 1251, Version: FoxPro, MemoBlockSize: 32})
+	if err != nil {
+		t.Fatalf("NewWriterOptions(): %v", err)
+	}
+	w.SetMemoWriter(fpt)
+
+	pict := []byte{0x89, 'P', 'N', 'G', 0x1A, 0}
+	w.SetStringFieldValue(0, "Заметка")
+	w.SetBytesFieldValue(1, pict)
+	w.Write()
+	w.Flush()
+	if w.Err() != nil {
+		t.Fatalf("Writer: %v", w.Err())
+	}
+	if dbf.buf[0] != foxProMemoId {
+		t.Errorf("header Id: want: %#x, got: %#x", foxProMemoId, dbf.buf[0])
+	}
+
+	dbf.Seek(0, io.SeekStart)
+	r, err := NewReader(dbf)
+	if err != nil {
+		t.Fatalf("NewReader(): %v", err)
+	}
+	r.SetMemoReader(fpt)
+	if !r.Read() {
+		t.Fatalf("Read(): want: true")
+	}
+	if got := r.StringFieldValue(0); got != "Заметка" {
+		t.Errorf("r.StringFieldValue(0): want: %#v, got: %#v", "Заметка", got)
+	}
+	if got := r.BytesFieldValue(1); !bytes.Equal(got, pict) {
+		t.Errorf("r.BytesFieldValue(1): want: %#v, got: %#v", pict, got)
+	}
+	r.StringFieldValue(1)
+	if r.Err() == nil {
+		t.Errorf("r.StringFieldValue(1): picture field: error required")
+	}
+}
+
+func Test_NewWriterOptions_picture_dBase3(t *testing.T) {
+	fields := NewFields()
+	fields.AddPictureField("PICT")
+
+	_, err := NewWriterOptions(&memFile{}, fields, WriterOptions{Version: DBase3})
+	if err == nil {
+		t.Errorf("NewWriterOptions(): picture field in dBase III: error required")
+	}
+}
+
+func Test_memoWriter_write_DBase4(t *testing.T) {
+	f := &memFile{}
+	m, err := newMemoWriter(f, DBase4, 0)
+	if err != nil {
+		t.Fatalf("newMemoWriter(): %v", err)
+	}
+	b1, _ := m.write([]byte("Abc"), memoText)
+	b2, _ := m.write(bytes.Repeat([]byte("x"), 505), memoText)
+	m.flush()
+
+	if b1 != 1 || b2 != 2 {
+		t.Errorf("memoWriter.write(): blocks: want: 1, 2, got: %v, %v", b1, b2)
+	}
+	if next := binary.LittleEndian.Uint32(f.buf); next != 4 {
+		t.Errorf("memo file next block: want: %v, got: %v", 4, next)
+	}
+	if size := binary.LittleEndian.Uint16(f.buf[20:]); size != 512 {
+		t.Errorf("memo file block size: want: %v, got: %v", 512, size)
+	}
+	want := []byte{0xFF, 0xFF, 0x08, 0x00, 11, 0, 0, 0, 'A', 'b', 'c'}
+	if got := f.buf[512 : 512+len(want)]; !bytes.Equal(got, want) {
+		t.Errorf("memo block:\nwant: %#v\ngot : %#v", want, got)
+	}
+}
+
+func Test_newMemoWriter_DBase4_block_size(t *testing.T) {
+	if _, err := newMemoWriter(&memFile{}, DBase4, 100); err == nil {
+		t.Errorf("newMemoWriter(100): error required")
+	}
+	if _, err := newMemoWriter(&memFile{}, DBase4, 1024); err != nil {
+		t.Errorf("newMemoWriter(1024): %v", err)
+	}
+}
+
+func Test_openMemoWriter_header(t *testing.T) {
+	f := &memFile{}
+	m, _ := newMemoWriter(f, DBase4, 0)
+	m.write([]byte("Abc"), memoText)
+	m.flush()
+	// dBase IV file name
+	copy(f.buf[8:], "NOTES")
+
+	m, err := openMemoWriter(f, DBase4)
+	if err != nil {
+		t.Fatalf("openMemoWriter(): %v", err)
+	}
+	block, _ := m.write([]byte("Def"), memoText)
+	m.flush()
+
+	if block != 2 {
+		t.Errorf("memoWriter.write(): block: want: %v, got: %v", 2, block)
+	}
+	if next := binary.LittleEndian.Uint32(f.buf); next != 3 {
+		t.Errorf("memo file next block: want: %v, got: %v", 3, next)
+	}
+	if name := string(f.buf[8:13]); name != "NOTES" {
+		t.Errorf("memo file name: want: %v, got: %v", "NOTES", name)
+	}
+	if size := binary.LittleEndian.Uint16(f.buf[20:]); size != 512 {
+		t.Errorf("memo file block size: want: %v, got: %v", 512, size)
+	}
+}
+
+func Test_memoReader_read_DBase4(t *testing.T) {
+	f := &memFile{}
+	m, _ := newMemoWriter(f, DBase4, 1024)
+	text := "Abc" + string(memoEnd) + strings.Repeat("z", 2000)
+	block, _ := m.write([]byte(text), memoText)
+	m.flush()
+
+	r, err := newMemoReader(f, DBase4)
+	if err != nil {
+		t.Fatalf("newMemoReader(): %v", err)
+	}
+	if r.blockSize != 1024 {
+		t.Errorf("memoReader.blockSize: want: %v, got: %v", 1024, r.blockSize)
+	}
+	got, err := r.read(block)
+	if err != nil {
+		t.Errorf("memoReader.read(%d): %v", block, err)
+	}
+	if string(got) != text {
+		t.Errorf("memoReader.read(%d): want: %#v, got: %#v", block, text, string(got))
+	}
+}
+
+func Test_Writer_Reader_memo_DBase4(t *testing.T) {
+	fields := NewFields()
+	fields.AddMemoField("NOTE")
+
+	dbf := &memFile{}
+	dbt := &memFile{}
+
+	w, _ := NewWriterOptions(dbf, fields, WriterOptions{Version: DBase4})
+	w.SetMemoWriter(dbt)
+	w.SetStringFieldValue(0, "dBase IV memo")
+	w.Write()
+	w.Flush()
+	if w.Err() != nil {
+		t.Fatalf("Writer: %v", w.Err())
+	}
+	if dbf.buf[0] != dBase4MemoId {
+		t.Errorf("header Id: want: %#x, got: %#x", dBase4MemoId, dbf.buf[0])
+	}
+
+	dbf.Seek(0, io.SeekStart)
+	r, _ := NewReader(dbf)
+	r.SetMemoReader(dbt)
+	r.Read()
+	if got := r.StringFieldValue(0); got != "dBase IV memo" {
+		t.Errorf("r.StringFieldValue(0): want: %#v, got: %#v", "dBase IV memo", got)
+	}
+	if r.Err() != nil {
+		t.Errorf("Reader: %v", r.Err())
+	}
+}
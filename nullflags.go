@@ -0,0 +1,204 @@
+package dbf
+
+import "fmt"
+
+// Visual FoxPro stores the null and varlength bits of fields
+// in the hidden system field _NullFlags. The bits are assigned
+// to the fields in the order of the fields; a nullable varlength
+// field has the null bit followed by the varlength bit.
+
+const nullFlagsName = "_NullFlags"
+
+func newNullFlagsField(bitCount int) *field {
+	f := &field{}
+	copy(f.Name[:], nullFlagsName)
+	f.Type = '0'
+	f.Len = byte((bitCount + 7) / 8)
+	f.Flags = byte(FlagSystem | FlagBinary)
+	return f
+}
+
+func (f *field) isNullFlags() bool {
+	return f.Type == '0'
+}
+
+func (f *field) isVarLength() bool {
+	return f.Type == 'V' || f.Type == 'Q'
+}
+
+func (f *field) isNullable() bool {
+	return f.flags()&FlagNullable != 0
+}
+
+// layoutNullFlags assigns the bits of the _NullFlags field to the fields.
+func (f *Fields) layoutNullFlags() {
+	f.nullFlags = nil
+	f.nullBits = make([]int, len(f.items))
+	f.lengthBits = make([]int, len(f.items))
+	bit := 0
+	for i, item := range f.items {
+		f.nullBits[i] = -1
+		f.lengthBits[i] = -1
+		if item.isNullFlags() {
+			f.nullFlags = item
+			continue
+		}
+		if item.isNullable() {
+			f.nullBits[i] = bit
+			bit++
+		}
+		if item.isVarLength() {
+			f.lengthBits[i] = bit
+			bit++
+		}
+	}
+}
+
+// nullFlagsBitCount returns the number of bits required by the fields.
+func (f *Fields) nullFlagsBitCount() int {
+	count := 0
+	for _, item := range f.items {
+		if item.isNullFlags() {
+			continue
+		}
+		if item.isNullable() {
+			count++
+		}
+		if item.isVarLength() {
+			count++
+		}
+	}
+	return count
+}
+
+// setNullFlags replaces the _NullFlags field with a new one
+// sized for the fields. The field is added last.
+func (f *Fields) setNullFlags() {
+	items := f.items[:0]
+	for _, item := range f.items {
+		if !item.isNullFlags() {
+			items = append(items, item)
+		}
+	}
+	f.items = items
+	if count := f.nullFlagsBitCount(); count > 0 {
+		f.items = append(f.items, newNullFlagsField(count))
+	}
+	f.updateOffsets()
+	f.updateIndex()
+	f.layoutNullFlags()
+}
+
+func (f *Fields) nullFlagsBit(recordBuf []byte, bit int) (bool, error) {
+	if f.nullFlags == nil || bit >= int(f.nullFlags.Len)*8 {
+		return false, fmt.Errorf("field %s not found", nullFlagsName)
+	}
+	buf := f.nullFlags.fieldBuf(recordBuf)
+	return buf[bit/8]&(1<<(bit%8)) != 0, nil
+}
+
+func (f *Fields) setNullFlagsBit(recordBuf []byte, bit int, value bool) error {
+	if f.nullFlags == nil || bit >= int(f.nullFlags.Len)*8 {
+		return fmt.Errorf("field %s not found", nullFlagsName)
+	}
+	buf := f.nullFlags.fieldBuf(recordBuf)
+	if value {
+		buf[bit/8] |= 1 << (bit % 8)
+	} else {
+		buf[bit/8] &^= 1 << (bit % 8)
+	}
+	return nil
+}
+
+// Varchar and varbinary field value
+
+func (f *Fields) varFieldValue(index int, recordBuf []byte) ([]byte, error) {
+	item := f.items[index]
+	buf := item.fieldBuf(recordBuf)
+	short, err := f.nullFlagsBit(recordBuf, f.lengthBits[index])
+	if err != nil {
+		return nil, err
+	}
+	if !short {
+		return buf, nil
+	}
+	n := int(buf[len(buf)-1])
+	if n >= len(buf) {
+		return nil, fmt.Errorf("invalid value length %d, field len %d", n, len(buf))
+	}
+	return buf[:n], nil
+}
+
+func (f *Fields) setVarFieldValue(index int, recordBuf []byte, value []byte) error {
+	item := f.items[index]
+	buf := item.fieldBuf(recordBuf)
+	if len(value) > len(buf) {
+		return fmt.Errorf("field value %q %w: value len %d, field len %d", value, ErrFieldOverflow, len(value), len(buf))
+	}
+	short := len(value) < len(buf)
+	if err := f.setNullFlagsBit(recordBuf, f.lengthBits[index], short); err != nil {
+		return err
+	}
+	n := copy(buf, value)
+	for i := n; i < len(buf); i++ {
+		buf[i] = 0
+	}
+	if short {
+		buf[len(buf)-1] = byte(len(value))
+	}
+	return nil
+}
+
+// Null value
+
+// isNull reports whether the field value is null.
+// A nullable field is null if its null bit is set.
+// Other Visual FoxPro fields are never null.
+// Other fields are null if they are blank.
+// Binary fields are never null except in dBase 7 files.
+func (f *Fields) isNull(index int, recordBuf []byte) (bool, error) {
+	if err := f.checkFieldIndex(index); err != nil {
+		return false, err
+	}
+	if bit := f.nullBits[index]; bit >= 0 {
+		return f.nullFlagsBit(recordBuf, bit)
+	}
+	if f.visualFoxPro {
+		return false, nil
+	}
+	item := f.items[index]
+	buf := item.fieldBuf(recordBuf)
+	if item.isBinaryValue() {
+		// dBase 7 binary fields are blank if zero
+		return item.level7 && isZero(buf), nil
+	}
+	if item.Type == 'L' && buf[0] == '?' {
+		return true, nil
+	}
+	return isEmpty(buf), nil
+}
+
+// setNull sets the field value to null.
+func (f *Fields) setNull(index int, recordBuf []byte) error {
+	if err := f.checkFieldIndex(index); err != nil {
+		return err
+	}
+	item := f.items[index]
+	if bit := f.nullBits[index]; bit >= 0 {
+		item.clear(recordBuf)
+		return f.setNullFlagsBit(recordBuf, bit, true)
+	}
+	if f.visualFoxPro || item.isBinaryValue() && !item.level7 {
+		return fmt.Errorf("field %q is not nullable", item.name())
+	}
+	item.clear(recordBuf)
+	return nil
+}
+
+// setNotNull clears the null bit of a nullable field.
+func (f *Fields) setNotNull(index int, recordBuf []byte) error {
+	if bit := f.nullBits[index]; bit >= 0 {
+		return f.setNullFlagsBit(recordBuf, bit, false)
+	}
+	return nil
+}
@@ -0,0 +1,296 @@
+package dbf
+
+import (
+	"bytes"
+	"io"
+	"testing"
+	"time"
+)
+
+func Test_Fields_setNullFlags(t *testing.T) {
+	f := NewFields()
+	f.AddCharacterField("name", 6)
+	f.AddVarcharField("code", 4)
+	f.AddVarbinaryField("data", 3)
+	f.setNullFlags()
+
+	if f.Count() != 4 {
+		t.Fatalf("Fields.setNullFlags(): f.Count(): want: %v, got: %v", 4, f.Count())
+	}
+	name, typ, length, _ := f.FieldInfo(3)
+	if name != nullFlagsName || typ != "0" || length != 1 {
+		t.Errorf("Fields.setNullFlags(): FieldInfo(3): want: %v 0 1, got: %v %v %v", nullFlagsName, name, typ, length)
+	}
+	if f.FieldFlags(3) != FlagSystem|FlagBinary {
+		t.Errorf("Fields.setNullFlags(): FieldFlags(3): want: %#x, got: %#x", FlagSystem|FlagBinary, f.FieldFlags(3))
+	}
+	if f.recSize != 1+6+4+3+1 {
+		t.Errorf("Fields.setNullFlags(): f.recSize: want: %v, got: %v", 1+6+4+3+1, f.recSize)
+	}
+	if f.lengthBits[1] != 0 || f.lengthBits[2] != 1 {
+		t.Errorf("Fields.setNullFlags(): lengthBits: want: [-1 0 1], got: %v", f.lengthBits)
+	}
+
+	// Repeated call does not add field
+	f.setNullFlags()
+	if f.Count() != 4 {
+		t.Errorf("Fields.setNullFlags(): repeated: f.Count(): want: %v, got: %v", 4, f.Count())
+	}
+}
+
+func Test_Fields_varFieldValue(t *testing.T) {
+	f := NewFields()
+	f.AddVarcharField("code", 4)
+	f.setNullFlags()
+
+	tests := []struct {
+		value   string
+		buf     []byte
+		nullBit byte
+	}{
+		{value: "ab ", buf: []byte{'a', 'b', ' ', 3}, nullBit: 1},
+		{value: "abcd", buf: []byte{'a', 'b', 'c', 'd'}, nullBit: 0},
+		{value: "", buf: []byte{0, 0, 0, 0}, nullBit: 1},
+	}
+	for _, tc := range tests {
+		buf := make([]byte, f.recSize)
+		if err := f.setStringFieldValue(0, buf, tc.value, nil, nil); err != nil {
+			t.Errorf("Fields.setStringFieldValue(%#v): %v", tc.value, err)
+		}
+		if !bytes.Equal(buf[1:5], tc.buf) || buf[5] != tc.nullBit {
+			t.Errorf("Fields.setStringFieldValue(%#v): want: %#v %v, got: %#v %v", tc.value, tc.buf, tc.nullBit, buf[1:5], buf[5])
+		}
+		got, _ := f.stringFieldValue(0, buf, nil, nil)
+		if got != tc.value {
+			t.Errorf("Fields.stringFieldValue(): want: %#v, got: %#v", tc.value, got)
+		}
+	}
+
+	buf := make([]byte, f.recSize)
+	if err := f.setStringFieldValue(0, buf, "abcde", nil, nil); err == nil {
+		t.Errorf("Fields.setStringFieldValue(%#v): overflow: error required", "abcde")
+	}
+}
+
+func Test_Writer_Reader_varchar(t *testing.T) {
+	fields := NewFields()
+	fields.AddVarcharField("NAME", 10)
+	fields.AddVarbinaryField("DATA", 4)
+
+	dbf := &memFile{}
+	w, err := NewWriterOptions(dbf, fields, WriterOptions{CodePage: 866, Version: VisualFoxPro})
+	if err != nil {
+		t.Fatalf("NewWriterOptions(): %v", err)
+	}
+
+	records := []struct {
+		name string
+		data []byte
+	}{
+		{"Мышь  ", []byte{0, 1, 2, 3}},
+		{"", []byte{' '}},
+	}
+	for _, rec := range records {
+		w.SetStringFieldValue(0, rec.name)
+		w.SetBytesFieldValue(1, rec.data)
+		w.Write()
+	}
+	w.Flush()
+	if w.Err() != nil {
+		t.Fatalf("Writer: %v", w.Err())
+	}
+	if dbf.buf[0] != visualFoxProVarId {
+		t.Errorf("header Id: want: %#x, got: %#x", visualFoxProVarId, dbf.buf[0])
+	}
+
+	dbf.Seek(0, io.SeekStart)
+	r, err := NewReader(dbf)
+	if err != nil {
+		t.Fatalf("NewReader(): %v", err)
+	}
+	i := 0
+	for r.Read() {
+		want := records[i]
+		i++
+		if got := r.StringFieldValue(0); got != want.name {
+			t.Errorf("r.StringFieldValue(0): want: %#v, got: %#v", want.name, got)
+		}
+		if got := r.BytesFieldValue(1); !bytes.Equal(got, want.data) {
+			t.Errorf("r.BytesFieldValue(1): want: %#v, got: %#v", want.data, got)
+		}
+	}
+	if r.Err() != nil {
+		t.Errorf("Reader: %v", r.Err())
+	}
+}
+
+func Test_Fields_isNull_blank(t *testing.T) {
+	f := NewFields()
+	f.AddCharacterField("name", 3)
+	f.AddLogicalField("flag")
+	f.AddNumericField("count", 3, 0)
+	f.AddIntegerField("id")
+
+	tests := []struct {
+		buf  []byte
+		want []bool
+	}{
+		{buf: []byte("    ?   \x00\x00\x00\x00"), want: []bool{true, true, true, false}},
+		{buf: []byte(" AbcT  1\x01\x00\x00\x00"), want: []bool{false, false, false, false}},
+		{buf: []byte(" a  F  0\x00\x00\x00\x00"), want: []bool{false, false, false, false}},
+	}
+	for _, tc := range tests {
+		for i, want := range tc.want {
+			got, err := f.isNull(i, tc.buf)
+			if err != nil {
+				t.Errorf("Fields.isNull(%d, %#v): %v", i, string(tc.buf), err)
+			}
+			if got != want {
+				t.Errorf("Fields.isNull(%d, %#v): want: %v, got: %v", i, string(tc.buf), want, got)
+			}
+		}
+	}
+
+	buf := []byte(" AbcT  1\x01\x00\x00\x00")
+	f.setNull(0, buf)
+	if string(buf[1:4]) != "   " {
+		t.Errorf("Fields.setNull(0): want: %#v, got: %#v", "   ", string(buf[1:4]))
+	}
+	if err := f.setNull(3, buf); err == nil {
+		t.Errorf("Fields.setNull(3): integer field: error required")
+	}
+}
+
+func Test_Writer_Reader_VisualFoxPro_not_nullable(t *testing.T) {
+	fields := NewFields()
+	fields.AddCharacterField("NAME", 3)
+	fields.AddCharacterField("CODE", 3)
+	fields.SetFieldFlags(1, FlagNullable)
+
+	f := &memFile{}
+	w, err := NewWriterOptions(f, fields, WriterOptions{Version: VisualFoxPro})
+	if err != nil {
+		t.Fatalf("NewWriterOptions(): %v", err)
+	}
+	w.SetNull(0)
+	if w.Err() == nil {
+		t.Errorf("w.SetNull(0): not nullable field: error required")
+	}
+
+	f = &memFile{}
+	w, _ = NewWriterOptions(f, fields, WriterOptions{Version: VisualFoxPro})
+	w.SetNull(1)
+	w.Write()
+	w.Flush()
+	if w.Err() != nil {
+		t.Fatalf("Writer: %v", w.Err())
+	}
+
+	f.Seek(0, io.SeekStart)
+	r, err := NewReader(f)
+	if err != nil {
+		t.Fatalf("NewReader(): %v", err)
+	}
+	r.Read()
+	if r.IsNull(0) {
+		t.Errorf("r.IsNull(0): blank not nullable field: want: false")
+	}
+	if !r.IsNull(1) {
+		t.Errorf("r.IsNull(1): want: true")
+	}
+	if r.Err() != nil {
+		t.Errorf("Reader: %v", r.Err())
+	}
+}
+
+func Test_Fields_layoutNullFlags_nullable(t *testing.T) {
+	f := NewFields()
+	f.AddIntegerField("id")
+	f.AddVarcharField("code", 4)
+	f.AddDateField("date")
+	f.SetFieldFlags(1, FlagNullable)
+	f.SetFieldFlags(2, FlagNullable)
+	f.setNullFlags()
+
+	wantNull := []int{-1, 0, 2, -1}
+	wantLength := []int{-1, 1, -1, -1}
+	for i := range wantNull {
+		if f.nullBits[i] != wantNull[i] || f.lengthBits[i] != wantLength[i] {
+			t.Errorf("Fields.layoutNullFlags(): field %d: want: %v %v, got: %v %v", i, wantNull[i], wantLength[i], f.nullBits[i], f.lengthBits[i])
+		}
+	}
+}
+
+func Test_Writer_Reader_null(t *testing.T) {
+	fields := NewFields()
+	fields.AddIntegerField("ID")
+	fields.AddNumericField("COUNT", 5, 0)
+	fields.AddDateTimeField("TIME")
+	fields.AddVarcharField("NAME", 6)
+	fields.SetFieldFlags(1, FlagNullable)
+	fields.SetFieldFlags(2, FlagNullable)
+	fields.SetFieldFlags(3, FlagNullable)
+
+	dbf := &memFile{}
+	w, err := NewWriterOptions(dbf, fields, WriterOptions{Version: VisualFoxPro})
+	if err != nil {
+		t.Fatalf("NewWriterOptions(): %v", err)
+	}
+	// Record 1: all values set
+	w.SetIntFieldValue(0, 1)
+	w.SetIntFieldValue(1, 0)
+	w.SetDateTimeFieldValue(2, time.Date(2021, 2, 12, 10, 0, 0, 0, time.UTC))
+	w.SetStringFieldValue(3, "")
+	w.Write()
+	// Record 2: nulls
+	w.SetNull(1)
+	w.SetNull(2)
+	w.SetNull(3)
+	w.Write()
+	w.Flush()
+	if w.Err() != nil {
+		t.Fatalf("Writer: %v", w.Err())
+	}
+	w.SetNull(0)
+	if w.Err() == nil {
+		t.Errorf("SetNull(0): not nullable field: error required")
+	}
+
+	wantNull := [][]bool{
+		{false, false, false, false},
+		{false, true, true, true},
+	}
+
+	dbf.Seek(0, io.SeekStart)
+	r, err := NewReader(dbf)
+	if err != nil {
+		t.Fatalf("NewReader(): %v", err)
+	}
+	i := 0
+	for r.Read() {
+		for j, want := range wantNull[i] {
+			if got := r.IsNull(j); got != want {
+				t.Errorf("record %d: r.IsNull(%d): want: %v, got: %v", i+1, j, want, got)
+			}
+		}
+		if i == 0 {
+			if v := r.NullIntFieldValue(1); !v.Valid || v.Int64 != 0 {
+				t.Errorf("record 1: r.NullIntFieldValue(1): want: {0 true}, got: %v", v)
+			}
+			if v := r.NullStringFieldValue(3); !v.Valid || v.String != "" {
+				t.Errorf("record 1: r.NullStringFieldValue(3): want: {\"\" true}, got: %v", v)
+			}
+		} else {
+			if v := r.NullDateTimeFieldValue(2); v.Valid {
+				t.Errorf("record 2: r.NullDateTimeFieldValue(2): want: invalid, got: %v", v)
+			}
+		}
+		i++
+	}
+	if r.Err() != nil {
+		t.Errorf("Reader: %v", r.Err())
+	}
+	if i != 2 {
+		t.Errorf("records read: want: %v, got: %v", 2, i)
+	}
+}
@@ -0,0 +1,247 @@
+package dbf
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"io"
+	"os"
+	"time"
+)
+
+// Pack copies the records of the DBF file src which are not deleted to dst.
+// The header and the fields are copied unchanged except the record count
+// and the modified date.
+// The memo fields keep their blocks, so the memo file of src
+// can be used with dst. Use PackMemo to compact the memo file as well.
+func Pack(src io.Reader, dst io.WriteSeeker) error {
+	if src == nil || dst == nil {
+		return fmt.Errorf("dbf.Pack: parameter is nil")
+	}
+	if _, err := pack(src, dst, nil, nil); err != nil {
+		return fmt.Errorf("dbf.Pack: %w", err)
+	}
+	return nil
+}
+
+// PackMemo is like Pack but also copies the memo data of the copied records
+// from srcMemo to dstMemo. The memo blocks which are not referenced
+// by the copied records are not copied.
+func PackMemo(src io.Reader, srcMemo io.ReaderAt, dst, dstMemo io.WriteSeeker) error {
+	if src == nil || srcMemo == nil || dst == nil || dstMemo == nil {
+		return fmt.Errorf("dbf.PackMemo: parameter is nil")
+	}
+	if _, err := pack(src, dst, srcMemo, dstMemo); err != nil {
+		return fmt.Errorf("dbf.PackMemo: %w", err)
+	}
+	return nil
+}
+
+// PackFile removes the deleted records from the DBF file f in place
+// and truncates f. If memo is not nil, the memo file is compacted
+// in place as well; the new memo data are written to a temporary file
+// first and then copied to memo.
+// The files are left inconsistent if an error occurs,
+// so make a backup copy before packing.
+func PackFile(f, memo *os.File) error {
+	if f == nil {
+		return fmt.Errorf("dbf.PackFile: parameter is nil")
+	}
+	if err := packFile(f, memo); err != nil {
+		return fmt.Errorf("dbf.PackFile: %w", err)
+	}
+	return nil
+}
+
+func packFile(f, memo *os.File) error {
+	fi, err := f.Stat()
+	if err != nil {
+		return err
+	}
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	// The records are read ahead of the written ones
+	src := io.NewSectionReader(f, 0, fi.Size())
+	if memo == nil {
+		h, err := pack(src, f, nil, nil)
+		if err != nil {
+			return err
+		}
+		return f.Truncate(h.fileSize())
+	}
+	tmp, err := os.CreateTemp("", "dbfmemo")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(tmp.Name())
+	defer tmp.Close()
+
+	h, err := pack(src, f, memo, tmp)
+	if err != nil {
+		return err
+	}
+	if err := f.Truncate(h.fileSize()); err != nil {
+		return err
+	}
+	// Replace the memo file data
+	if _, err := tmp.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if _, err := memo.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	n, err := io.Copy(memo, tmp)
+	if err != nil {
+		return err
+	}
+	return memo.Truncate(n)
+}
+
+// pack copies the records which are not deleted from src to dst
+// and returns the header written to dst.
+// If srcMemo is not nil, the memo data are copied to dstMemo.
+func pack(src io.Reader, dst io.WriteSeeker, srcMemo io.ReaderAt, dstMemo io.WriteSeeker) (*header, error) {
+	raw, r, err := readRawHeader(src)
+	if err != nil {
+		return nil, err
+	}
+	var mw *memoWriter
+	blocks := make(map[uint32]uint32)
+	if srcMemo != nil {
+		mr, err := newMemoReader(srcMemo, r.version)
+		if err != nil {
+			return nil, err
+		}
+		r.memo = mr
+		mw, err = newMemoWriter(dstMemo, r.version, mr.blockSize)
+		if err != nil {
+			return nil, err
+		}
+	}
+	writer := bufio.NewWriter(dst)
+	if _, err := writer.Write(raw); err != nil {
+		return nil, err
+	}
+	var count uint32
+	for i := uint32(0); i < r.header.RecCount && r.Read(); i++ {
+		if r.Deleted() {
+			continue
+		}
+		if mw != nil {
+			if err := r.fields.copyMemo(r.buf, r.memo, mw, blocks); err != nil {
+				return nil, &RecordError{RecNo: r.recNo, Err: err}
+			}
+		}
+		if _, err := writer.Write(r.buf); err != nil {
+			return nil, err
+		}
+		count++
+	}
+	if r.err != nil {
+		return nil, r.err
+	}
+	if err := writer.WriteByte(fileEnd); err != nil {
+		return nil, err
+	}
+	if err := writer.Flush(); err != nil {
+		return nil, err
+	}
+	// modify record count in header
+	if _, err := dst.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	r.header.RecCount = count
+	r.header.setModDate(time.Now())
+	if err := r.header.write(dst); err != nil {
+		return nil, err
+	}
+	if mw != nil {
+		if err := mw.flush(); err != nil {
+			return nil, err
+		}
+	}
+	return r.header, nil
+}
+
+// readRawHeader reads the data of src up to the first record.
+// It returns the data and a Reader which reads the records from src.
+func readRawHeader(src io.Reader) ([]byte, *Reader, error) {
+	raw := make([]byte, headerSize)
+	if _, err := io.ReadFull(src, raw); err != nil {
+		return nil, nil, err
+	}
+	h := &header{}
+	if err := h.read(bytes.NewReader(raw)); err != nil {
+		return nil, nil, err
+	}
+	if int(h.DataOffset) < headerSize {
+		return nil, nil, fmt.Errorf("invalid data offset %d", h.DataOffset)
+	}
+	raw = append(raw, make([]byte, int(h.DataOffset)-headerSize)...)
+	if _, err := io.ReadFull(src, raw[headerSize:]); err != nil {
+		return nil, nil, err
+	}
+	r, err := newReader(bytes.NewReader(raw))
+	if err != nil {
+		return nil, nil, err
+	}
+	r.reader = bufio.NewReader(src)
+	return raw, r, nil
+}
+
+// Zap removes all records from the DBF file f and truncates f.
+// The header and the fields are kept unchanged except the record count
+// and the modified date. If memo is not nil, the memo file is reset
+// to an empty memo file with the same block size.
+func Zap(f, memo *os.File) error {
+	if f == nil {
+		return fmt.Errorf("dbf.Zap: parameter is nil")
+	}
+	if err := zap(f, memo); err != nil {
+		return fmt.Errorf("dbf.Zap: %w", err)
+	}
+	return nil
+}
+
+func zap(f, memo *os.File) error {
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	_, r, err := readRawHeader(f)
+	if err != nil {
+		return err
+	}
+	r.header.RecCount = 0
+	r.header.setModDate(time.Now())
+	if _, err := f.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	if err := r.header.write(f); err != nil {
+		return err
+	}
+	if _, err := f.WriteAt([]byte{fileEnd}, int64(r.header.DataOffset)); err != nil {
+		return err
+	}
+	if err := f.Truncate(r.header.fileSize()); err != nil {
+		return err
+	}
+	if memo == nil {
+		return nil
+	}
+	mr, err := newMemoReader(memo, r.version)
+	if err != nil {
+		return err
+	}
+	if err := memo.Truncate(0); err != nil {
+		return err
+	}
+	if _, err := memo.Seek(0, io.SeekStart); err != nil {
+		return err
+	}
+	mw, err := newMemoWriter(memo, r.version, mr.blockSize)
+	if err != nil {
+		return err
+	}
+	return mw.flush()
+}
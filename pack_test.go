@@ -0,0 +1,244 @@
+package dbf
+
+import (
+	"io"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// testPackFiles writes a FoxPro file with 4 records,
+// the records 2 and 4 are deleted.
+func testPackFiles(t *testing.T, dbf, memo io.WriteSeeker) {
+	fields := NewFields()
+	fields.AddCharacterField("NAME", 10)
+	fields.AddMemoField("NOTE")
+
+	w, err := NewWriterOptions(dbf, fields, WriterOptions{CodePage: 866, Version: FoxPro})
+	if err != nil {
+		t.Fatalf("NewWriterOptions(): %v", err)
+	}
+	w.SetMemoWriter(memo)
+	for i, name := range []string{"Мышь", "Кот", "Пёс", "Ёж"} {
+		w.SetDeteted(i%2 == 1)
+		w.SetStringFieldValue(0, name)
+		w.SetStringFieldValue(1, "Note "+name)
+		w.Write()
+	}
+	w.Flush()
+	if w.Err() != nil {
+		t.Fatalf("Writer: %v", w.Err())
+	}
+}
+
+func testPacked(t *testing.T, dbf io.Reader, memo io.ReaderAt) {
+	r, err := NewReader(dbf)
+	if err != nil {
+		t.Fatalf("NewReader(): %v", err)
+	}
+	if memo != nil {
+		r.SetMemoReader(memo)
+	}
+	if r.RecordCount() != 2 {
+		t.Errorf("r.RecordCount(): want: %v, got: %v", 2, r.RecordCount())
+	}
+	if r.CodePage() != 866 {
+		t.Errorf("r.CodePage(): want: %v, got: %v", 866, r.CodePage())
+	}
+	var got []string
+	for r.Read() {
+		if r.Deleted() {
+			t.Errorf("record %d: deleted", r.RecNo())
+		}
+		got = append(got, r.StringFieldValue(0))
+		if memo != nil {
+			got = append(got, r.StringFieldValue(1))
+		}
+	}
+	if r.Err() != nil {
+		t.Fatalf("Reader: %v", r.Err())
+	}
+	want := []string{"Мышь", "Пёс"}
+	if memo != nil {
+		want = []string{"Мышь", "Note Мышь", "Пёс", "Note Пёс"}
+	}
+	if len(got) != len(want) {
+		t.Fatalf("values: want: %v, got: %v", want, got)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Errorf("values: want: %v, got: %v", want, got)
+			break
+		}
+	}
+}
+
+func Test_Pack(t *testing.T) {
+	src, srcMemo := &memFile{}, &memFile{}
+	testPackFiles(t, src, srcMemo)
+	src.Seek(0, io.SeekStart)
+
+	dst := &memFile{}
+	if err := Pack(src, dst); err != nil {
+		t.Fatalf("Pack(): %v", err)
+	}
+	dst.Seek(0, io.SeekStart)
+	// Memo blocks are not changed
+	testPacked(t, dst, srcMemo)
+}
+
+func Test_PackMemo(t *testing.T) {
+	src, srcMemo := &memFile{}, &memFile{}
+	testPackFiles(t, src, srcMemo)
+	src.Seek(0, io.SeekStart)
+
+	dst, dstMemo := &memFile{}, &memFile{}
+	if err := PackMemo(src, srcMemo, dst, dstMemo); err != nil {
+		t.Fatalf("PackMemo(): %v", err)
+	}
+	if len(dstMemo.buf) >= len(srcMemo.buf) {
+		t.Errorf("memo size: want: < %v, got: %v", len(srcMemo.buf), len(dstMemo.buf))
+	}
+	dst.Seek(0, io.SeekStart)
+	testPacked(t, dst, dstMemo)
+}
+
+func Test_PackMemo_shared_block(t *testing.T) {
+	fields := NewFields()
+	fields.AddCharacterField("NAME", 10)
+	fields.AddMemoField("NOTE")
+
+	src, srcMemo := &memFile{}, &memFile{}
+	w, err := NewWriterOptions(src, fields, WriterOptions{Version: FoxPro})
+	if err != nil {
+		t.Fatalf("NewWriterOptions(): %v", err)
+	}
+	w.SetMemoWriter(srcMemo)
+	// The records share the memo block
+	w.SetStringFieldValue(1, "Note")
+	for _, name := range []string{"Mouse", "Cat", "Dog"} {
+		w.SetStringFieldValue(0, name)
+		w.Write()
+	}
+	w.Flush()
+	if w.Err() != nil {
+		t.Fatalf("Writer: %v", w.Err())
+	}
+	src.Seek(0, io.SeekStart)
+
+	dst, dstMemo := &memFile{}, &memFile{}
+	if err := PackMemo(src, srcMemo, dst, dstMemo); err != nil {
+		t.Fatalf("PackMemo(): %v", err)
+	}
+	if len(dstMemo.buf) != len(srcMemo.buf) {
+		t.Errorf("memo size: want: %v, got: %v", len(srcMemo.buf), len(dstMemo.buf))
+	}
+	dst.Seek(0, io.SeekStart)
+	r, err := NewReader(dst)
+	if err != nil {
+		t.Fatalf("NewReader(): %v", err)
+	}
+	r.SetMemoReader(dstMemo)
+	for r.Read() {
+		if got := r.StringFieldValue(1); got != "Note" {
+			t.Errorf("record %d: r.StringFieldValue(1): want: %v, got: %v", r.RecNo(), "Note", got)
+		}
+	}
+	if r.Err() != nil {
+		t.Errorf("Reader: %v", r.Err())
+	}
+}
+
+func Test_PackFile(t *testing.T) {
+	dir := t.TempDir()
+	f, err := os.Create(filepath.Join(dir, "test.dbf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	memo, err := os.Create(filepath.Join(dir, "test.fpt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer memo.Close()
+	testPackFiles(t, f, memo)
+	memoInfo, _ := memo.Stat()
+
+	if err := PackFile(f, memo); err != nil {
+		t.Fatalf("PackFile(): %v", err)
+	}
+	fi, _ := f.Stat()
+	if want := int64(headerSize + 2*fieldSize + 1 + 2*21 + 1); fi.Size() != want {
+		t.Errorf("file size: want: %v, got: %v", want, fi.Size())
+	}
+	if mi, _ := memo.Stat(); mi.Size() >= memoInfo.Size() {
+		t.Errorf("memo size: want: < %v, got: %v", memoInfo.Size(), mi.Size())
+	}
+	f.Seek(0, io.SeekStart)
+	testPacked(t, f, memo)
+
+	if err := PackFile(nil, nil); err == nil {
+		t.Errorf("PackFile(nil): error required")
+	}
+}
+
+func Test_Zap(t *testing.T) {
+	dir := t.TempDir()
+	f, err := os.Create(filepath.Join(dir, "test.dbf"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	memo, err := os.Create(filepath.Join(dir, "test.fpt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer memo.Close()
+	testPackFiles(t, f, memo)
+
+	if err := Zap(f, memo); err != nil {
+		t.Fatalf("Zap(): %v", err)
+	}
+	fi, _ := f.Stat()
+	if want := int64(headerSize + 2*fieldSize + 1 + 1); fi.Size() != want {
+		t.Errorf("file size: want: %v, got: %v", want, fi.Size())
+	}
+	if mi, _ := memo.Stat(); mi.Size() != memoHeaderSize {
+		t.Errorf("memo size: want: %v, got: %v", memoHeaderSize, mi.Size())
+	}
+
+	f.Seek(0, io.SeekStart)
+	r, err := NewReader(f)
+	if err != nil {
+		t.Fatalf("NewReader(): %v", err)
+	}
+	if r.RecordCount() != 0 || r.CodePage() != 866 || r.Fields().Count() != 2 {
+		t.Errorf("header: want: %v %v %v, got: %v %v %v", 0, 866, 2, r.RecordCount(), r.CodePage(), r.Fields().Count())
+	}
+	if r.Read() {
+		t.Errorf("r.Read(): want: false")
+	}
+
+	// Append to the empty file
+	a, err := OpenAppender(f)
+	if err != nil {
+		t.Fatalf("OpenAppender(): %v", err)
+	}
+	a.SetMemoAppender(memo)
+	a.SetStringFieldValue(0, "Мышь")
+	a.SetStringFieldValue(1, "Note Мышь")
+	a.Write()
+	a.Flush()
+	if a.Err() != nil {
+		t.Fatalf("Appender: %v", a.Err())
+	}
+	f.Seek(0, io.SeekStart)
+	r, err = NewReader(f)
+	if err != nil {
+		t.Fatalf("NewReader(): %v", err)
+	}
+	r.SetMemoReader(memo)
+	if !r.Read() || r.StringFieldValue(1) != "Note Мышь" {
+		t.Errorf("appended record: want: %v, got: %v", "Note Мышь", r.StringFieldValue(1))
+	}
+}
@@ -2,6 +2,8 @@ package dbf
 
 import (
 	"bufio"
+	"bytes"
+	"database/sql"
 	"fmt"
 	"io"
 	"time"
@@ -11,25 +13,54 @@ import (
 
 // The Reader reads records from a DBF file.
 type Reader struct {
-	header  *header
-	fields  *Fields
-	reader  *bufio.Reader
-	buf     []byte
-	recNo   uint32
-	decoder *encoding.Decoder
-	err     error
+	header     *header
+	fields     *Fields
+	reader     *bufio.Reader
+	ra         io.ReaderAt
+	buf        []byte
+	scratch    []byte
+	recNo      uint32
+	eof        bool
+	decoder    *encoding.Decoder
+	memo       *memoReader
+	version    Version
+	backlink   string
+	langDriver string
+	skipDel    bool
+	strict     bool
+	warnings   []error
+	err        error
 }
 
 // NewReader returns a new Reader that reads from rd.
-func NewReader(rd io.Reader) (r *Reader, err error) {
-	defer func() {
-		if err != nil {
-			err = fmt.Errorf("dbf.NewReader: %w", err)
-		}
-	}()
+func NewReader(rd io.Reader) (*Reader, error) {
 	if rd == nil {
-		return nil, fmt.Errorf("parameter is nil")
+		return nil, fmt.Errorf("dbf.NewReader: parameter is nil")
+	}
+	r, err := newReader(rd)
+	if err != nil {
+		return nil, fmt.Errorf("dbf.NewReader: %w", err)
+	}
+	return r, nil
+}
+
+// NewReaderAt returns a new Reader that reads from ra of the given size.
+// The Reader supports random access to the records by GoTo.
+func NewReaderAt(ra io.ReaderAt, size int64) (*Reader, error) {
+	if ra == nil {
+		return nil, fmt.Errorf("dbf.NewReaderAt: parameter is nil")
 	}
+	sr := io.NewSectionReader(ra, 0, size)
+	r, err := newReader(sr)
+	if err != nil {
+		return nil, fmt.Errorf("dbf.NewReaderAt: %w", err)
+	}
+	r.ra = sr
+	return r, nil
+}
+
+// newReader reads the header and the fields from rd.
+func newReader(rd io.Reader) (r *Reader, err error) {
 	r = &Reader{
 		header: &header{},
 		fields: NewFields(),
@@ -38,15 +69,54 @@ func NewReader(rd io.Reader) (r *Reader, err error) {
 	if err = r.header.read(r.reader); err != nil {
 		return nil, err
 	}
-	if err = r.fields.read(r.reader, r.header.fieldCount()); err != nil {
-		return nil, err
+	r.version = r.header.version()
+	if next, _ := r.reader.Peek(fieldSize); r.header.isLevel7(next) {
+		r.version = DBase7
+	}
+	if r.version == DBase7 {
+		// Language driver name
+		buf := make([]byte, level7HeaderSize-headerSize)
+		if _, err = io.ReadFull(r.reader, buf); err != nil {
+			return nil, err
+		}
+		buf = buf[:languageDriverLen]
+		if i := bytes.IndexByte(buf, 0); i >= 0 {
+			buf = buf[:i]
+		}
+		r.langDriver = string(buf)
+		if err = r.fields.readLevel7(r.reader); err != nil {
+			return nil, err
+		}
+	} else {
+		if err = r.fields.read(r.reader, r.header.fieldCount(r.version), r.version); err != nil {
+			return nil, err
+		}
+		r.fields.visualFoxPro = r.version == VisualFoxPro
 	}
 	// Skip byte header end
 	if _, err = r.reader.Discard(1); err != nil {
 		return nil, err
 	}
+	skip := int(r.header.DataOffset) - r.version.headerSize() - r.fields.Count()*r.version.fieldSize() - 1
+	// Database container path
+	if n := r.version.backlinkSize(); n > 0 && skip >= n {
+		buf := make([]byte, n)
+		if _, err = io.ReadFull(r.reader, buf); err != nil {
+			return nil, err
+		}
+		if i := bytes.IndexByte(buf, 0); i >= 0 {
+			buf = buf[:i]
+		}
+		r.backlink = string(buf)
+		skip -= n
+	}
+	// Skip other data up to the first record
+	if _, err = r.reader.Discard(skip); err != nil {
+		return nil, err
+	}
 	// Create buffer
 	r.buf = make([]byte, int(r.header.RecSize))
+	r.scratch = make([]byte, int(r.header.RecSize))
 	// Code page
 	if cp := r.header.codePage(); cp != 0 {
 		cm := charmapByPage(cp)
@@ -86,13 +156,58 @@ func (r *Reader) SetCodePage(cp int) {
 	}
 	cm := charmapByPage(cp)
 	if cm == nil {
-		r.err = fmt.Errorf("SetCodePage: unsupported code page %d", cp)
+		r.err = fmt.Errorf("SetCodePage: %w %d", ErrUnsupportedCodePage, cp)
 		return
 	}
 	r.decoder = cm.NewDecoder()
 	r.header.setCodePage(cp)
 }
 
+// SetMemoReader sets the memo file used to read memo fields.
+// The memo file format (.DBT or .FPT) is defined by the file version.
+func (r *Reader) SetMemoReader(ra io.ReaderAt) {
+	if r.err != nil {
+		return
+	}
+	if ra == nil {
+		r.err = fmt.Errorf("SetMemoReader: parameter is nil")
+		return
+	}
+	memo, err := newMemoReader(ra, r.version)
+	if err != nil {
+		r.err = fmt.Errorf("SetMemoReader: %w", err)
+		return
+	}
+	r.memo = memo
+}
+
+// Version returns the file version defined by the file header.
+func (r *Reader) Version() Version {
+	if r.err != nil {
+		return 0
+	}
+	return r.version
+}
+
+// Backlink returns the path to the Visual FoxPro database container (.DBC)
+// which the table belongs to.
+// Returns an empty string for a free table.
+func (r *Reader) Backlink() string {
+	if r.err != nil {
+		return ""
+	}
+	return r.backlink
+}
+
+// LanguageDriver returns the language driver name of a dBase 7 file.
+// Returns an empty string for other versions.
+func (r *Reader) LanguageDriver() string {
+	if r.err != nil {
+		return ""
+	}
+	return r.langDriver
+}
+
 // CodePage returns the code page set in the file header.
 func (r *Reader) CodePage() int {
 	if r.err != nil {
@@ -125,20 +240,141 @@ func (r *Reader) Fields() *Fields {
 	return r.fields
 }
 
+// SetSkipDeleted sets whether Read skips the records marked as deleted.
+// RecNo still returns the physical number of the record.
+// GoTo reads a deleted record regardless of the setting.
+func (r *Reader) SetSkipDeleted(skip bool) {
+	if r.err != nil {
+		return
+	}
+	r.skipDel = skip
+}
+
 // Read reads one record from r.
 // Returns false if end of file is reached or an error occurs.
+// See SetStrict for how the end of file is detected.
+// Once the end is reached, Read returns false until GoTo is called.
+// The deleted records are skipped if set by SetSkipDeleted.
 func (r *Reader) Read() bool {
+	for r.read() {
+		if !r.skipDel || r.buf[0] != '*' {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *Reader) read() bool {
+	if r.err != nil || r.eof {
+		return false
+	}
+	recNo := r.recNo + 1
+	if r.strict && recNo > r.header.RecCount {
+		r.eof = true
+		return false
+	}
+	if r.ra != nil {
+		return r.readAt("Read", recNo)
+	}
+	n, err := io.ReadFull(r.reader, r.scratch)
+	return r.checkRecord("Read", recNo, n, err)
+}
+
+// GoTo reads the record by number. The first record number is 1.
+// Returns false if the record does not exist or an error occurs;
+// the current record is then unchanged.
+// The Reader must be created by NewReaderAt.
+// The next Read reads the record following recNo.
+func (r *Reader) GoTo(recNo uint32) bool {
 	if r.err != nil {
 		return false
 	}
-	r.recNo++
-	if _, err := io.ReadFull(r.reader, r.buf); err != nil {
-		if err != io.EOF && err != io.ErrUnexpectedEOF {
-			r.err = fmt.Errorf("Read: record %d: %w", r.recNo, err)
-		}
+	if r.ra == nil {
+		r.err = fmt.Errorf("GoTo: random access not supported, use NewReaderAt")
+		return false
+	}
+	if recNo == 0 || r.strict && recNo > r.header.RecCount {
+		return false
+	}
+	return r.readAt("GoTo", recNo)
+}
+
+func (r *Reader) readAt(fn string, recNo uint32) bool {
+	off := int64(r.header.DataOffset) + int64(recNo-1)*int64(r.header.RecSize)
+	n, err := r.ra.ReadAt(r.scratch, off)
+	if n == len(r.scratch) {
+		err = nil
+	}
+	return r.checkRecord(fn, recNo, n, err)
+}
+
+// checkRecord checks the result of reading n bytes of the record recNo
+// into the scratch buffer. The record becomes the current one
+// only if it is read completely.
+// In strict mode the missing data of a record declared in the header,
+// or the end of file marker in its place, is an error.
+// Otherwise Read stops at the end of file marker or the end of data,
+// and a mismatch with the header is reported by Warnings.
+func (r *Reader) checkRecord(fn string, recNo uint32, n int, err error) bool {
+	if err == nil && r.scratch[0] != fileEnd {
+		r.buf, r.scratch = r.scratch, r.buf
+		r.recNo = recNo
+		r.eof = false
+		return true
+	}
+	if err != nil && err != io.EOF && err != io.ErrUnexpectedEOF {
+		r.err = fmt.Errorf("%s: %w", fn, &RecordError{RecNo: recNo, Err: err})
+		return false
+	}
+	if r.strict {
+		r.err = fmt.Errorf("%s: %w", fn, &RecordError{RecNo: recNo, Err: ErrTruncated})
+		return false
+	}
+	if fn != "Read" {
 		return false
 	}
-	return true
+	r.eof = true
+	if n > 0 && r.scratch[0] != fileEnd {
+		r.warn(&RecordError{RecNo: recNo, Err: fmt.Errorf("%w: %d of %d bytes", ErrTruncated, n, len(r.scratch))})
+	}
+	if count := recNo - 1; count != r.header.RecCount {
+		r.warn(fmt.Errorf("%w: %d in header, %d in file", ErrRecordCount, r.header.RecCount, count))
+	}
+	return false
+}
+
+func (r *Reader) warn(err error) {
+	r.warnings = append(r.warnings, err)
+}
+
+// SetStrict sets the strict mode of reading.
+// In strict mode the records are read up to the record count in the header,
+// the data shorter than declared or the end of file marker in place
+// of a record is an error wrapping ErrTruncated.
+// By default the records are read up to the end of file marker
+// or the end of data, and a mismatch with the header is reported by Warnings.
+func (r *Reader) SetStrict(strict bool) {
+	if r.err != nil {
+		return
+	}
+	r.strict = strict
+}
+
+// Warnings returns the problems found in the file data
+// which did not stop the reading, e.g. a truncated last record
+// or a record count different from the header.
+// The warnings wrap ErrTruncated or ErrRecordCount.
+func (r *Reader) Warnings() []error {
+	return append([]error(nil), r.warnings...)
+}
+
+// RecNo returns the number of the current record.
+// The first record number is 1.
+func (r *Reader) RecNo() uint32 {
+	if r.err != nil {
+		return 0
+	}
+	return r.recNo
 }
 
 // Deleted returns deleted record flag.
@@ -150,14 +386,32 @@ func (r *Reader) Deleted() bool {
 }
 
 // StringFieldValue returns the value of the field by index.
-// Field type must be Character, Date, Logical or Numeric.
+// Field type must be Character, Date, Logical, Numeric, Float, Double,
+// Integer, Currency, DateTime, Varchar or Memo.
+// A DateTime value is returned in the format YYYYMMDDhhmmss.
+// For a memo field the memo file must be set by SetMemoReader.
 func (r *Reader) StringFieldValue(index int) string {
 	if r.err != nil {
 		return ""
 	}
-	value, err := r.fields.stringFieldValue(index, r.buf, r.decoder)
+	value, err := r.fields.stringFieldValue(index, r.buf, r.decoder, r.memo)
 	if err != nil {
-		r.err = fmt.Errorf("StringFieldValue: %w", err)
+		r.err = r.valueError("StringFieldValue", index, err)
+	}
+	return value
+}
+
+// BytesFieldValue returns the value of the field by index.
+// Field type must be Memo, General, Picture, Blob, Varchar or Varbinary.
+// For a memo type field the memo file must be set by SetMemoReader.
+// The value of a Memo or Varchar field is returned without decoding.
+func (r *Reader) BytesFieldValue(index int) []byte {
+	if r.err != nil {
+		return nil
+	}
+	value, err := r.fields.bytesFieldValue(index, r.buf, r.memo)
+	if err != nil {
+		r.err = r.valueError("BytesFieldValue", index, err)
 	}
 	return value
 }
@@ -170,7 +424,7 @@ func (r *Reader) BoolFieldValue(index int) bool {
 	}
 	value, err := r.fields.boolFieldValue(index, r.buf)
 	if err != nil {
-		r.err = fmt.Errorf("BoolFieldValue: %w", err)
+		r.err = r.valueError("BoolFieldValue", index, err)
 	}
 	return value
 }
@@ -183,13 +437,13 @@ func (r *Reader) DateFieldValue(index int) time.Time {
 	}
 	value, err := r.fields.dateFieldValue(index, r.buf)
 	if err != nil {
-		r.err = fmt.Errorf("DateFieldValue: %w", err)
+		r.err = r.valueError("DateFieldValue", index, err)
 	}
 	return value
 }
 
 // IntFieldValue returns the value of the field by index.
-// Field type must be Numeric.
+// Field type must be Numeric, Float or Integer.
 // If field decimal places is not zero,
 // then it returns the integer part of the number.
 func (r *Reader) IntFieldValue(index int) int64 {
@@ -198,20 +452,329 @@ func (r *Reader) IntFieldValue(index int) int64 {
 	}
 	value, err := r.fields.intFieldValue(index, r.buf)
 	if err != nil {
-		r.err = fmt.Errorf("IntFieldValue: %w", err)
+		r.err = r.valueError("IntFieldValue", index, err)
 	}
 	return value
 }
 
 // FloatFieldValue returns the value of the field by index.
-// Field type must be Numeric.
+// Field type must be Numeric, Float, Double or Currency.
 func (r *Reader) FloatFieldValue(index int) float64 {
 	if r.err != nil {
 		return 0
 	}
 	value, err := r.fields.floatFieldValue(index, r.buf)
 	if err != nil {
-		r.err = fmt.Errorf("FloatFieldValue: %w", err)
+		r.err = r.valueError("FloatFieldValue", index, err)
+	}
+	return value
+}
+
+// CurrencyFieldValue returns the value of the field by index
+// as an integer scaled by 10000, e.g. 12.5 is returned as 125000.
+// Field type must be Currency.
+func (r *Reader) CurrencyFieldValue(index int) int64 {
+	if r.err != nil {
+		return 0
+	}
+	value, err := r.fields.currencyFieldValue(index, r.buf)
+	if err != nil {
+		r.err = r.valueError("CurrencyFieldValue", index, err)
+	}
+	return value
+}
+
+// DateTimeFieldValue returns the value of the field by index
+// with millisecond precision.
+// Field type must be DateTime.
+func (r *Reader) DateTimeFieldValue(index int) time.Time {
+	if r.err != nil {
+		return time.Time{}
+	}
+	value, err := r.fields.dateTimeFieldValue(index, r.buf)
+	if err != nil {
+		r.err = r.valueError("DateTimeFieldValue", index, err)
+	}
+	return value
+}
+
+// IsNull reports whether the value of the field by index is null.
+// A Visual FoxPro nullable field is null if its null flag is set,
+// other Visual FoxPro fields are never null.
+// Fields of other versions are null if they are blank,
+// a Logical field is also null if its value is '?'.
+// Binary fields which are not nullable are never null.
+func (r *Reader) IsNull(index int) bool {
+	if r.err != nil {
+		return false
+	}
+	value, err := r.fields.isNull(index, r.buf)
+	if err != nil {
+		r.err = r.valueError("IsNull", index, err)
 	}
 	return value
 }
+
+// NullStringFieldValue returns the value of the field by index.
+// Valid is false if the value is null.
+// See StringFieldValue for the field types.
+func (r *Reader) NullStringFieldValue(index int) sql.NullString {
+	if r.IsNull(index) {
+		return sql.NullString{}
+	}
+	value := r.StringFieldValue(index)
+	return sql.NullString{String: value, Valid: r.err == nil}
+}
+
+// NullBoolFieldValue returns the value of the field by index.
+// Valid is false if the value is null.
+// Field type must be Logical.
+func (r *Reader) NullBoolFieldValue(index int) sql.NullBool {
+	if r.IsNull(index) {
+		return sql.NullBool{}
+	}
+	value := r.BoolFieldValue(index)
+	return sql.NullBool{Bool: value, Valid: r.err == nil}
+}
+
+// NullDateFieldValue returns the value of the field by index.
+// Valid is false if the value is null.
+// Field type must be Date.
+func (r *Reader) NullDateFieldValue(index int) sql.NullTime {
+	if r.IsNull(index) {
+		return sql.NullTime{}
+	}
+	value := r.DateFieldValue(index)
+	return sql.NullTime{Time: value, Valid: r.err == nil}
+}
+
+// NullDateTimeFieldValue returns the value of the field by index.
+// Valid is false if the value is null.
+// Field type must be DateTime.
+func (r *Reader) NullDateTimeFieldValue(index int) sql.NullTime {
+	if r.IsNull(index) {
+		return sql.NullTime{}
+	}
+	value := r.DateTimeFieldValue(index)
+	return sql.NullTime{Time: value, Valid: r.err == nil}
+}
+
+// NullIntFieldValue returns the value of the field by index.
+// Valid is false if the value is null.
+// See IntFieldValue for the field types.
+func (r *Reader) NullIntFieldValue(index int) sql.NullInt64 {
+	if r.IsNull(index) {
+		return sql.NullInt64{}
+	}
+	value := r.IntFieldValue(index)
+	return sql.NullInt64{Int64: value, Valid: r.err == nil}
+}
+
+// NullFloatFieldValue returns the value of the field by index.
+// Valid is false if the value is null.
+// See FloatFieldValue for the field types.
+func (r *Reader) NullFloatFieldValue(index int) sql.NullFloat64 {
+	if r.IsNull(index) {
+		return sql.NullFloat64{}
+	}
+	value := r.FloatFieldValue(index)
+	return sql.NullFloat64{Float64: value, Valid: r.err == nil}
+}
+
+// Decode stores the values of the current record in the struct pointed to by v.
+// A struct field is mapped to the DBF field by the name in the tag `dbf:"NAME"`
+// or by the struct field name; names are case insensitive.
+// The struct fields with the tag `dbf:"-"` and the struct fields
+// not found in the file are left unchanged.
+//
+// Supported struct field types: string, []byte, bool, int*, uint*, float*,
+// time.Time and the types implementing encoding.TextUnmarshaler.
+// A pointer struct field is set to nil if the value is null.
+func (r *Reader) Decode(v interface{}) error {
+	if r.err != nil {
+		return r.Err()
+	}
+	rv, err := structValue("Decode", v)
+	if err != nil {
+		return fmt.Errorf("dbf.Reader: %w", err)
+	}
+	for _, sf := range structFields(rv.Type()) {
+		index, ok := r.fields.FieldIndex(sf.name)
+		if !ok {
+			continue
+		}
+		if err := r.decodeValue(index, rv.FieldByIndex(sf.index)); err != nil {
+			r.err = r.valueError("Decode", index, err)
+			return r.Err()
+		}
+	}
+	return nil
+}
+
+// valueError returns err with the record and field context.
+func (r *Reader) valueError(fn string, index int, err error) error {
+	return fmt.Errorf("%s: %w", fn, &RecordError{RecNo: r.recNo, Err: r.fields.fieldError(index, err)})
+}
+
+// Field value by name
+
+func (r *Reader) fieldIndex(fn, name string) (int, bool) {
+	if r.err != nil {
+		return 0, false
+	}
+	index, ok := r.fields.FieldIndex(name)
+	if !ok {
+		r.err = fmt.Errorf("%s: field %q not found", fn, name)
+	}
+	return index, ok
+}
+
+// StringFieldValueByName returns the value of the field by name.
+// See StringFieldValue.
+func (r *Reader) StringFieldValueByName(name string) string {
+	index, ok := r.fieldIndex("StringFieldValueByName", name)
+	if !ok {
+		return ""
+	}
+	return r.StringFieldValue(index)
+}
+
+// BoolFieldValueByName returns the value of the field by name.
+// See BoolFieldValue.
+func (r *Reader) BoolFieldValueByName(name string) bool {
+	index, ok := r.fieldIndex("BoolFieldValueByName", name)
+	if !ok {
+		return false
+	}
+	return r.BoolFieldValue(index)
+}
+
+// DateFieldValueByName returns the value of the field by name.
+// See DateFieldValue.
+func (r *Reader) DateFieldValueByName(name string) time.Time {
+	index, ok := r.fieldIndex("DateFieldValueByName", name)
+	if !ok {
+		return time.Time{}
+	}
+	return r.DateFieldValue(index)
+}
+
+// IntFieldValueByName returns the value of the field by name.
+// See IntFieldValue.
+func (r *Reader) IntFieldValueByName(name string) int64 {
+	index, ok := r.fieldIndex("IntFieldValueByName", name)
+	if !ok {
+		return 0
+	}
+	return r.IntFieldValue(index)
+}
+
+// FloatFieldValueByName returns the value of the field by name.
+// See FloatFieldValue.
+func (r *Reader) FloatFieldValueByName(name string) float64 {
+	index, ok := r.fieldIndex("FloatFieldValueByName", name)
+	if !ok {
+		return 0
+	}
+	return r.FloatFieldValue(index)
+}
+
+// IsNullByName reports whether the value of the field by name is null.
+// See IsNull.
+func (r *Reader) IsNullByName(name string) bool {
+	index, ok := r.fieldIndex("IsNullByName", name)
+	if !ok {
+		return false
+	}
+	return r.IsNull(index)
+}
+
+// BytesFieldValueByName returns the value of the field by name.
+// See BytesFieldValue.
+func (r *Reader) BytesFieldValueByName(name string) []byte {
+	index, ok := r.fieldIndex("BytesFieldValueByName", name)
+	if !ok {
+		return nil
+	}
+	return r.BytesFieldValue(index)
+}
+
+// CurrencyFieldValueByName returns the value of the field by name.
+// See CurrencyFieldValue.
+func (r *Reader) CurrencyFieldValueByName(name string) int64 {
+	index, ok := r.fieldIndex("CurrencyFieldValueByName", name)
+	if !ok {
+		return 0
+	}
+	return r.CurrencyFieldValue(index)
+}
+
+// DateTimeFieldValueByName returns the value of the field by name.
+// See DateTimeFieldValue.
+func (r *Reader) DateTimeFieldValueByName(name string) time.Time {
+	index, ok := r.fieldIndex("DateTimeFieldValueByName", name)
+	if !ok {
+		return time.Time{}
+	}
+	return r.DateTimeFieldValue(index)
+}
+
+// NullStringFieldValueByName returns the value of the field by name.
+// See NullStringFieldValue.
+func (r *Reader) NullStringFieldValueByName(name string) sql.NullString {
+	index, ok := r.fieldIndex("NullStringFieldValueByName", name)
+	if !ok {
+		return sql.NullString{}
+	}
+	return r.NullStringFieldValue(index)
+}
+
+// NullBoolFieldValueByName returns the value of the field by name.
+// See NullBoolFieldValue.
+func (r *Reader) NullBoolFieldValueByName(name string) sql.NullBool {
+	index, ok := r.fieldIndex("NullBoolFieldValueByName", name)
+	if !ok {
+		return sql.NullBool{}
+	}
+	return r.NullBoolFieldValue(index)
+}
+
+// NullDateFieldValueByName returns the value of the field by name.
+// See NullDateFieldValue.
+func (r *Reader) NullDateFieldValueByName(name string) sql.NullTime {
+	index, ok := r.fieldIndex("NullDateFieldValueByName", name)
+	if !ok {
+		return sql.NullTime{}
+	}
+	return r.NullDateFieldValue(index)
+}
+
+// NullDateTimeFieldValueByName returns the value of the field by name.
+// See NullDateTimeFieldValue.
+func (r *Reader) NullDateTimeFieldValueByName(name string) sql.NullTime {
+	index, ok := r.fieldIndex("NullDateTimeFieldValueByName", name)
+	if !ok {
+		return sql.NullTime{}
+	}
+	return r.NullDateTimeFieldValue(index)
+}
+
+// NullIntFieldValueByName returns the value of the field by name.
+// See NullIntFieldValue.
+func (r *Reader) NullIntFieldValueByName(name string) sql.NullInt64 {
+	index, ok := r.fieldIndex("NullIntFieldValueByName", name)
+	if !ok {
+		return sql.NullInt64{}
+	}
+	return r.NullIntFieldValue(index)
+}
+
+// NullFloatFieldValueByName returns the value of the field by name.
+// See NullFloatFieldValue.
+func (r *Reader) NullFloatFieldValueByName(name string) sql.NullFloat64 {
+	index, ok := r.fieldIndex("NullFloatFieldValueByName", name)
+	if !ok {
+		return sql.NullFloat64{}
+	}
+	return r.NullFloatFieldValue(index)
+}
@@ -1,7 +1,12 @@
 package dbf
 
 import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"io"
 	"os"
+	"reflect"
 	"testing"
 	"time"
 )
@@ -35,6 +40,9 @@ func Test_NewReader(t *testing.T) {
 	if r.CodePage() != 866 {
 		t.Errorf("NewReader(): r.CodePage(): want: %v, got: %v", 866, r.CodePage())
 	}
+	if r.Version() != DBase3 {
+		t.Errorf("NewReader(): r.Version(): want: %v, got: %v", DBase3, r.Version())
+	}
 
 	testFields := []struct {
 		Name, Type string
@@ -188,3 +196,274 @@ func Test_Reader_Read_records(t *testing.T) {
 		}
 	}
 }
+
+func Test_Reader_GoTo(t *testing.T) {
+	fname := "./testdata/rec3.dbf"
+	f, err := os.Open(fname)
+	if err != nil {
+		t.Fatalf("os.Open(%q): %v", fname, err)
+	}
+	defer f.Close()
+	fi, err := f.Stat()
+	if err != nil {
+		t.Fatalf("f.Stat(): %v", err)
+	}
+
+	r, err := NewReaderAt(f, fi.Size())
+	if err != nil {
+		t.Fatalf("NewReaderAt(): %v", err)
+	}
+
+	tests := []struct {
+		recNo uint32
+		ok    bool
+		name  string
+	}{
+		{recNo: 3, ok: true, name: "Мышь"},
+		{recNo: 1, ok: true, name: "Abc"},
+		{recNo: 0, ok: false},
+		{recNo: 4, ok: false},
+	}
+	for _, tc := range tests {
+		ok := r.GoTo(tc.recNo)
+		if ok != tc.ok {
+			t.Errorf("r.GoTo(%d): want: %v, got: %v", tc.recNo, tc.ok, ok)
+		}
+		if !ok {
+			continue
+		}
+		if r.RecNo() != tc.recNo {
+			t.Errorf("r.RecNo(): want: %v, got: %v", tc.recNo, r.RecNo())
+		}
+		if got := r.StringFieldValue(0); got != tc.name {
+			t.Errorf("r.GoTo(%d): r.StringFieldValue(0): want: %#v, got: %#v", tc.recNo, tc.name, got)
+		}
+	}
+	if r.Err() != nil {
+		t.Errorf("Reader: %v", r.Err())
+	}
+
+	// Read continues after the current record
+	r.GoTo(1)
+	if !r.Read() || r.RecNo() != 2 {
+		t.Errorf("r.Read() after r.GoTo(1): r.RecNo(): want: %v, got: %v", 2, r.RecNo())
+	}
+	if !r.Read() || r.Read() {
+		t.Errorf("r.Read(): want 1 more record")
+	}
+}
+
+func Test_Reader_GoTo_sequential(t *testing.T) {
+	fname := "./testdata/rec3.dbf"
+	f, err := os.Open(fname)
+	if err != nil {
+		t.Fatalf("os.Open(%q): %v", fname, err)
+	}
+	defer f.Close()
+
+	r, err := NewReader(f)
+	if err != nil {
+		t.Fatalf("NewReader(): %v", err)
+	}
+	if r.GoTo(1) || r.Err() == nil {
+		t.Errorf("r.GoTo(1): sequential reader: error required")
+	}
+}
+
+func Test_Reader_SetSkipDeleted(t *testing.T) {
+	f, memo := &memFile{}, &memFile{}
+	testPackFiles(t, f, memo)
+	f.Seek(0, io.SeekStart)
+
+	r, err := NewReader(f)
+	if err != nil {
+		t.Fatalf("NewReader(): %v", err)
+	}
+	r.SetSkipDeleted(true)
+	var got []uint32
+	for r.Read() {
+		if r.Deleted() {
+			t.Errorf("record %d: deleted", r.RecNo())
+		}
+		got = append(got, r.RecNo())
+	}
+	if r.Err() != nil {
+		t.Fatalf("Reader: %v", r.Err())
+	}
+	if want := []uint32{1, 3}; !reflect.DeepEqual(got, want) {
+		t.Errorf("RecNo(): want: %v, got: %v", want, got)
+	}
+}
+
+func Test_Reader_SetStrict(t *testing.T) {
+	// Modifies the file written by testProductFile with 2 records
+	tests := []struct {
+		name     string
+		modify   func(f *memFile, recSize int)
+		lenient  int
+		warnings []error
+		strict   int
+		err      error
+	}{
+		{
+			name:    "valid",
+			modify:  func(f *memFile, recSize int) {},
+			lenient: 2,
+			strict:  2,
+		},
+		{
+			name: "data after end of file marker",
+			modify: func(f *memFile, recSize int) {
+				f.buf = append(f.buf, make([]byte, 2*recSize)...)
+			},
+			lenient: 2,
+			strict:  2,
+		},
+		{
+			name: "garbage after end of file marker",
+			modify: func(f *memFile, recSize int) {
+				f.buf = append(f.buf, bytes.Repeat([]byte(" GARBAGE"), recSize)...)
+			},
+			lenient: 2,
+			strict:  2,
+		},
+		{
+			name: "no end of file marker",
+			modify: func(f *memFile, recSize int) {
+				f.buf = f.buf[:len(f.buf)-1]
+			},
+			lenient: 2,
+			strict:  2,
+		},
+		{
+			name: "record count greater than data",
+			modify: func(f *memFile, recSize int) {
+				f.buf[4] = 3
+			},
+			lenient:  2,
+			warnings: []error{ErrRecordCount},
+			strict:   2,
+			err:      ErrTruncated,
+		},
+		{
+			name: "data after end of file marker before record count",
+			modify: func(f *memFile, recSize int) {
+				f.buf[4] = 3
+				f.buf = append(f.buf, make([]byte, 2*recSize)...)
+			},
+			lenient:  2,
+			warnings: []error{ErrRecordCount},
+			strict:   2,
+			err:      ErrTruncated,
+		},
+		{
+			name: "record count less than data",
+			modify: func(f *memFile, recSize int) {
+				f.buf[4] = 1
+			},
+			lenient:  2,
+			warnings: []error{ErrRecordCount},
+			strict:   1,
+		},
+		{
+			name: "truncated record",
+			modify: func(f *memFile, recSize int) {
+				f.buf = f.buf[:len(f.buf)-1-recSize/2]
+			},
+			lenient:  1,
+			warnings: []error{ErrTruncated, ErrRecordCount},
+			strict:   1,
+			err:      ErrTruncated,
+		},
+	}
+	for _, tc := range tests {
+		for _, strict := range []bool{false, true} {
+			for _, random := range []bool{false, true} {
+				f := testProductFile(t)
+				recSize := int(binary.LittleEndian.Uint16(f.buf[10:]))
+				tc.modify(f, recSize)
+
+				var r *Reader
+				var err error
+				if random {
+					r, err = NewReaderAt(bytes.NewReader(f.buf), int64(len(f.buf)))
+				} else {
+					r, err = NewReader(f)
+				}
+				if err != nil {
+					t.Fatalf("%s: NewReader(): %v", tc.name, err)
+				}
+				r.SetStrict(strict)
+				count := 0
+				for r.Read() {
+					count++
+				}
+				want, wantErr, wantWarnings := tc.lenient, error(nil), tc.warnings
+				if strict {
+					want, wantErr, wantWarnings = tc.strict, tc.err, nil
+				}
+				if count != want {
+					t.Errorf("%s (strict %v, random %v): records: want: %v, got: %v", tc.name, strict, random, want, count)
+				}
+				// Read after the end
+				if r.Read() || r.Read() {
+					t.Errorf("%s (strict %v, random %v): r.Read() after end: want: false", tc.name, strict, random)
+				}
+				if wantErr == nil && r.RecNo() != uint32(want) {
+					t.Errorf("%s (strict %v, random %v): r.RecNo() after end: want: %v, got: %v", tc.name, strict, random, want, r.RecNo())
+				}
+				if err := r.Err(); !errors.Is(err, wantErr) || (err == nil) != (wantErr == nil) {
+					t.Errorf("%s (strict %v, random %v): r.Err(): want: %v, got: %v", tc.name, strict, random, wantErr, err)
+				}
+				warnings := r.Warnings()
+				if len(warnings) != len(wantWarnings) {
+					t.Errorf("%s (strict %v, random %v): r.Warnings(): want: %v, got: %v", tc.name, strict, random, wantWarnings, warnings)
+					continue
+				}
+				for i := range warnings {
+					if !errors.Is(warnings[i], wantWarnings[i]) {
+						t.Errorf("%s (strict %v, random %v): r.Warnings(): want: %v, got: %v", tc.name, strict, random, wantWarnings, warnings)
+					}
+				}
+			}
+		}
+	}
+}
+
+func Test_NewReaderAt_size(t *testing.T) {
+	f := testProductFile(t)
+	dataOffset := int64(binary.LittleEndian.Uint16(f.buf[8:]))
+	recSize := int64(binary.LittleEndian.Uint16(f.buf[10:]))
+
+	// The size covers the first record only
+	r, err := NewReaderAt(bytes.NewReader(f.buf), dataOffset+recSize)
+	if err != nil {
+		t.Fatalf("NewReaderAt(): %v", err)
+	}
+	if !r.GoTo(1) {
+		t.Errorf("r.GoTo(1): want: true")
+	}
+	if r.GoTo(2) {
+		t.Errorf("r.GoTo(2): record beyond size: want: false")
+	}
+	if r.Err() != nil {
+		t.Errorf("Reader: %v", r.Err())
+	}
+
+	// The size covers a half of the second record
+	r, err = NewReaderAt(bytes.NewReader(f.buf), dataOffset+recSize+recSize/2)
+	if err != nil {
+		t.Fatalf("NewReaderAt(): %v", err)
+	}
+	r.GoTo(1)
+	if r.GoTo(2) {
+		t.Errorf("r.GoTo(2): truncated record: want: false")
+	}
+	// The current record is unchanged
+	if r.RecNo() != 1 || r.StringFieldValue(0) != "Mouse" || r.IntFieldValue(1) != 12 {
+		t.Errorf("r.GoTo(2): current record changed: record %d", r.RecNo())
+	}
+	if r.Err() != nil {
+		t.Errorf("Reader: %v", r.Err())
+	}
+}
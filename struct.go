@@ -0,0 +1,382 @@
+package dbf
+
+import (
+	"encoding"
+	"fmt"
+	"math"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Struct mapping
+
+const structTag = "dbf"
+
+var (
+	timeType            = reflect.TypeOf(time.Time{})
+	bytesType           = reflect.TypeOf([]byte(nil))
+	textUnmarshalerType = reflect.TypeOf((*encoding.TextUnmarshaler)(nil)).Elem()
+	textMarshalerType   = reflect.TypeOf((*encoding.TextMarshaler)(nil)).Elem()
+)
+
+// structField is a struct field mapped to a DBF field.
+type structField struct {
+	index []int
+	name  string
+	// field type, length and decimal places from the tag
+	opts []string
+}
+
+var structFieldsCache sync.Map // map[reflect.Type][]structField
+
+// structFields returns the mapped fields of the struct type t.
+// A field is mapped by the name in the tag `dbf:"NAME"` or by the field name.
+// The fields with the tag `dbf:"-"` and unexported fields are skipped.
+// The fields of embedded structs are mapped as the fields of t.
+func structFields(t reflect.Type) []structField {
+	if fields, ok := structFieldsCache.Load(t); ok {
+		return fields.([]structField)
+	}
+	fields := appendStructFields(nil, t, nil)
+	structFieldsCache.Store(t, fields)
+	return fields
+}
+
+func appendStructFields(fields []structField, t reflect.Type, index []int) []structField {
+	for i := 0; i < t.NumField(); i++ {
+		sf := t.Field(i)
+		tag, hasTag := sf.Tag.Lookup(structTag)
+		if tag == "-" {
+			continue
+		}
+		idx := append(append([]int(nil), index...), i)
+		if sf.Anonymous && !hasTag && sf.Type.Kind() == reflect.Struct && sf.Type != timeType {
+			fields = appendStructFields(fields, sf.Type, idx)
+			continue
+		}
+		if sf.PkgPath != "" {
+			continue
+		}
+		parts := strings.Split(tag, ",")
+		name := strings.TrimSpace(parts[0])
+		if name == "" {
+			name = sf.Name
+		}
+		fields = append(fields, structField{index: idx, name: name, opts: parts[1:]})
+	}
+	return fields
+}
+
+// structValue returns the struct value pointed to by v.
+func structValue(fn string, v interface{}) (reflect.Value, error) {
+	rv := reflect.ValueOf(v)
+	if rv.Kind() != reflect.Ptr || rv.IsNil() || rv.Elem().Kind() != reflect.Struct {
+		return reflect.Value{}, fmt.Errorf("%s: want non-nil pointer to struct, got %T", fn, v)
+	}
+	return rv.Elem(), nil
+}
+
+// Decode
+
+// decodeValue stores the value of the field by index in v.
+func (r *Reader) decodeValue(index int, v reflect.Value) error {
+	item := r.fields.items[index]
+	if v.Kind() == reflect.Ptr {
+		null, err := r.fields.isNull(index, r.buf)
+		if err != nil {
+			return err
+		}
+		if null {
+			v.Set(reflect.Zero(v.Type()))
+			return nil
+		}
+		if v.IsNil() {
+			v.Set(reflect.New(v.Type().Elem()))
+		}
+		return r.decodeValue(index, v.Elem())
+	}
+	if v.Type() == timeType {
+		var value time.Time
+		var err error
+		if item.Type == 'T' || item.Type == '@' {
+			value, err = r.fields.dateTimeFieldValue(index, r.buf)
+		} else {
+			value, err = r.fields.dateFieldValue(index, r.buf)
+		}
+		if err != nil {
+			return err
+		}
+		v.Set(reflect.ValueOf(value))
+		return nil
+	}
+	if v.CanAddr() && v.Addr().Type().Implements(textUnmarshalerType) {
+		s, err := r.fields.stringFieldValue(index, r.buf, r.decoder, r.memo)
+		if err != nil {
+			return err
+		}
+		return v.Addr().Interface().(encoding.TextUnmarshaler).UnmarshalText([]byte(s))
+	}
+	if v.Type() == bytesType && (item.isMemo() || item.isVarLength()) {
+		value, err := r.fields.bytesFieldValue(index, r.buf, r.memo)
+		if err != nil {
+			return err
+		}
+		v.SetBytes(value)
+		return nil
+	}
+	switch v.Kind() {
+	case reflect.String:
+		value, err := r.fields.stringFieldValue(index, r.buf, r.decoder, r.memo)
+		if err != nil {
+			return err
+		}
+		v.SetString(value)
+	case reflect.Bool:
+		value, err := r.fields.boolFieldValue(index, r.buf)
+		if err != nil {
+			return err
+		}
+		v.SetBool(value)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		value, err := r.fields.intFieldValue(index, r.buf)
+		if err != nil {
+			return err
+		}
+		if v.OverflowInt(value) {
+			return fmt.Errorf("value %d %w: want %v", value, ErrFieldOverflow, v.Type())
+		}
+		v.SetInt(value)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		value, err := r.fields.intFieldValue(index, r.buf)
+		if err != nil {
+			return err
+		}
+		if value < 0 || v.OverflowUint(uint64(value)) {
+			return fmt.Errorf("value %d %w: want %v", value, ErrFieldOverflow, v.Type())
+		}
+		v.SetUint(uint64(value))
+	case reflect.Float32, reflect.Float64:
+		value, err := r.fields.floatFieldValue(index, r.buf)
+		if err != nil {
+			return err
+		}
+		v.SetFloat(value)
+	default:
+		return fmt.Errorf("%w: unsupported type %v", ErrTypeMismatch, v.Type())
+	}
+	return nil
+}
+
+// Encode
+
+// encodeValue assigns the value v to the field by index.
+func (w *Writer) encodeValue(index int, v reflect.Value) error {
+	item := w.fields.items[index]
+	if v.Kind() == reflect.Ptr {
+		if v.IsNil() {
+			return w.fields.setNull(index, w.buf)
+		}
+		return w.encodeValue(index, v.Elem())
+	}
+	if v.Type() == timeType {
+		value := v.Interface().(time.Time)
+		if item.Type == 'T' || item.Type == '@' {
+			return w.fields.setDateTimeFieldValue(index, w.buf, value)
+		}
+		if value.IsZero() {
+			return w.fields.setStringFieldValue(index, w.buf, "", w.encoder, w.memo)
+		}
+		return w.fields.setDateFieldValue(index, w.buf, value)
+	}
+	if v.CanAddr() && v.Addr().Type().Implements(textMarshalerType) {
+		v = v.Addr()
+	}
+	if v.Type().Implements(textMarshalerType) {
+		text, err := v.Interface().(encoding.TextMarshaler).MarshalText()
+		if err != nil {
+			return err
+		}
+		return w.fields.setStringFieldValue(index, w.buf, string(text), w.encoder, w.memo)
+	}
+	if v.Type() == bytesType && (item.isMemo() || item.isVarLength()) {
+		return w.fields.setBytesFieldValue(index, w.buf, v.Bytes(), w.memo)
+	}
+	switch v.Kind() {
+	case reflect.String:
+		return w.fields.setStringFieldValue(index, w.buf, v.String(), w.encoder, w.memo)
+	case reflect.Bool:
+		return w.fields.setBoolFieldValue(index, w.buf, v.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return w.fields.setIntFieldValue(index, w.buf, v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if v.Uint() > math.MaxInt64 {
+			return fmt.Errorf("value %d %w: want int64", v.Uint(), ErrFieldOverflow)
+		}
+		return w.fields.setIntFieldValue(index, w.buf, int64(v.Uint()))
+	case reflect.Float32, reflect.Float64:
+		return w.fields.setFloatFieldValue(index, w.buf, v.Float())
+	}
+	return fmt.Errorf("%w: unsupported type %v", ErrTypeMismatch, v.Type())
+}
+
+// Fields from struct
+
+// FieldsFromStruct returns the fields defined by the struct v
+// or the struct pointed to by v.
+// The struct fields are mapped as described in Reader.Decode.
+// The field type, length and decimal places can be set in the tag,
+// e.g. `dbf:"PRICE,N,12,2"`, `dbf:"NAME,C,40"` or `dbf:"NOTE,M"`.
+// If the tag sets the length only, the decimal places are 0.
+// Otherwise they are inferred from the struct field type:
+//     string, encoding.TextMarshaler - C 254
+//     bool                           - L
+//     time.Time                      - D
+//     int*, uint*                    - N with the length of the type, e.g. int64 - N 19,0
+//     float*                         - N 19,6
+//     []byte                         - M
+// A pointer struct field is defined by the type it points to.
+func FieldsFromStruct(v interface{}) (*Fields, error) {
+	t := reflect.TypeOf(v)
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("dbf.FieldsFromStruct: want struct or pointer to struct, got %T", v)
+	}
+	fields := NewFields()
+	for _, sf := range structFields(t) {
+		ft := t.FieldByIndex(sf.index).Type
+		if err := fields.addStructField(sf, ft); err != nil {
+			return nil, fmt.Errorf("dbf.FieldsFromStruct: field %q: %w", sf.name, err)
+		}
+	}
+	if fields.Count() == 0 {
+		return nil, fmt.Errorf("dbf.FieldsFromStruct: no fields defined")
+	}
+	return fields, nil
+}
+
+func (f *Fields) addStructField(sf structField, t reflect.Type) error {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	typ, length, dec := defaultFieldType(t)
+	hasLength := false
+	if len(sf.opts) > 3 {
+		return fmt.Errorf("too many tag options %q", strings.Join(sf.opts, ","))
+	}
+	if len(sf.opts) > 0 {
+		if s := strings.ToUpper(strings.TrimSpace(sf.opts[0])); s != "" {
+			if len(s) != 1 {
+				return fmt.Errorf("invalid field type %q", s)
+			}
+			if s[0] != typ {
+				length, dec = 0, 0
+			}
+			typ = s[0]
+		}
+	}
+	if len(sf.opts) > 1 {
+		n, err := strconv.Atoi(strings.TrimSpace(sf.opts[1]))
+		if err != nil {
+			return fmt.Errorf("invalid field length: %w", err)
+		}
+		length, dec, hasLength = n, 0, true
+	}
+	if len(sf.opts) > 2 {
+		n, err := strconv.Atoi(strings.TrimSpace(sf.opts[2]))
+		if err != nil {
+			return fmt.Errorf("invalid field decimal places: %w", err)
+		}
+		dec = n
+	}
+	if typ == 0 {
+		return fmt.Errorf("unsupported type %v", t)
+	}
+	if length == 0 && !hasLength {
+		switch typ {
+		case 'C', 'V', 'Q':
+			length = maxCharacterLen
+		case 'N':
+			length = maxNumericLen
+		case 'F':
+			length = maxFloatLen
+		}
+	}
+	switch typ {
+	case 'C':
+		f.AddCharacterField(sf.name, length)
+	case 'N':
+		f.AddNumericField(sf.name, length, dec)
+	case 'F':
+		f.AddFloatField(sf.name, length, dec)
+	case 'L':
+		f.AddLogicalField(sf.name)
+	case 'D':
+		f.AddDateField(sf.name)
+	case 'M':
+		f.AddMemoField(sf.name)
+	case 'I':
+		f.AddIntegerField(sf.name)
+	case 'Y':
+		f.AddCurrencyField(sf.name)
+	case 'T':
+		f.AddDateTimeField(sf.name)
+	case 'B':
+		f.AddDoubleField(sf.name, dec)
+	case 'V':
+		f.AddVarcharField(sf.name, length)
+	case 'Q':
+		f.AddVarbinaryField(sf.name, length)
+	case 'G':
+		f.AddGeneralField(sf.name)
+	case 'P':
+		f.AddPictureField(sf.name)
+	case 'W':
+		f.AddBlobField(sf.name)
+	default:
+		return fmt.Errorf("unsupported field type %q", typ)
+	}
+	return f.err
+}
+
+// defaultFieldType returns the field type, length and decimal places
+// inferred from the struct field type t.
+// The field type is zero if it cannot be inferred.
+func defaultFieldType(t reflect.Type) (typ byte, length, dec int) {
+	if t == timeType {
+		return 'D', 0, 0
+	}
+	if t.Implements(textMarshalerType) || reflect.PtrTo(t).Implements(textMarshalerType) {
+		return 'C', maxCharacterLen, 0
+	}
+	if t == bytesType {
+		return 'M', 0, 0
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return 'C', maxCharacterLen, 0
+	case reflect.Bool:
+		return 'L', 0, 0
+	case reflect.Int8:
+		return 'N', 4, 0
+	case reflect.Int16:
+		return 'N', 6, 0
+	case reflect.Int32:
+		return 'N', 11, 0
+	case reflect.Int, reflect.Int64, reflect.Uint, reflect.Uint64:
+		return 'N', maxNumericLen, 0
+	case reflect.Uint8:
+		return 'N', 3, 0
+	case reflect.Uint16:
+		return 'N', 5, 0
+	case reflect.Uint32:
+		return 'N', 10, 0
+	case reflect.Float32, reflect.Float64:
+		return 'N', maxNumericLen, 6
+	}
+	return 0, 0, 0
+}
@@ -0,0 +1,334 @@
+package dbf
+
+import (
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+type testCode string
+
+func (c *testCode) UnmarshalText(text []byte) error {
+	*c = testCode(strings.ToLower(string(text)))
+	return nil
+}
+
+type testBase struct {
+	Name string `dbf:"NAME"`
+}
+
+type testProduct struct {
+	testBase
+	Count   int       `dbf:"COUNT"`
+	Price   float64   `dbf:"PRICE"`
+	Flag    bool      `dbf:"FLAG"`
+	Date    time.Time `dbf:"DATE"`
+	Qty     *uint16   `dbf:"QTY"`
+	Code    testCode  `dbf:"CODE"`
+	Ignored string    `dbf:"-"`
+	Missing string
+	private string
+}
+
+func Test_structFields(t *testing.T) {
+	fields := structFields(reflect.TypeOf(testProduct{}))
+
+	want := []string{"NAME", "COUNT", "PRICE", "FLAG", "DATE", "QTY", "CODE", "Missing"}
+	got := make([]string, len(fields))
+	for i, sf := range fields {
+		got[i] = sf.name
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("structFields(): want: %v, got: %v", want, got)
+	}
+	if !reflect.DeepEqual(fields[0].index, []int{0, 0}) {
+		t.Errorf("structFields(): embedded field index: want: %v, got: %v", []int{0, 0}, fields[0].index)
+	}
+}
+
+func testProductFile(t *testing.T) *memFile {
+	fields := NewFields()
+	fields.AddCharacterField("NAME", 10)
+	fields.AddNumericField("COUNT", 5, 0)
+	fields.AddNumericField("PRICE", 10, 2)
+	fields.AddLogicalField("FLAG")
+	fields.AddDateField("DATE")
+	fields.AddNumericField("QTY", 5, 0)
+	fields.AddCharacterField("CODE", 5)
+
+	f := &memFile{}
+	w, err := NewWriter(f, fields, 0)
+	if err != nil {
+		t.Fatalf("NewWriter(): %v", err)
+	}
+	w.SetStringFieldValue(0, "Mouse")
+	w.SetIntFieldValue(1, 12)
+	w.SetFloatFieldValue(2, 1.25)
+	w.SetBoolFieldValue(3, true)
+	w.SetDateFieldValue(4, time.Date(2021, 2, 12, 0, 0, 0, 0, time.UTC))
+	w.SetIntFieldValue(5, 7)
+	w.SetStringFieldValue(6, "AB")
+	w.Write()
+	w.SetNull(5)
+	w.SetIntFieldValue(1, -1)
+	w.Write()
+	w.Flush()
+	if w.Err() != nil {
+		t.Fatalf("Writer: %v", w.Err())
+	}
+	f.Seek(0, io.SeekStart)
+	return f
+}
+
+func Test_Reader_Decode(t *testing.T) {
+	r, err := NewReader(testProductFile(t))
+	if err != nil {
+		t.Fatalf("NewReader(): %v", err)
+	}
+	if !r.Read() {
+		t.Fatalf("Read(): want: true")
+	}
+	var p testProduct
+	p.Missing = "keep"
+	if err := r.Decode(&p); err != nil {
+		t.Fatalf("Decode(): %v", err)
+	}
+	qty := uint16(7)
+	want := testProduct{
+		testBase: testBase{Name: "Mouse"},
+		Count:    12,
+		Price:    1.25,
+		Flag:     true,
+		Date:     time.Date(2021, 2, 12, 0, 0, 0, 0, time.UTC),
+		Qty:      &qty,
+		Code:     "ab",
+		Missing:  "keep",
+	}
+	if !reflect.DeepEqual(p, want) {
+		t.Errorf("Decode():\nwant: %+v\ngot : %+v", want, p)
+	}
+
+	// Null value and overflow
+	if !r.Read() {
+		t.Fatalf("Read(): want: true")
+	}
+	var n struct {
+		Qty *int `dbf:"QTY"`
+	}
+	n.Qty = new(int)
+	if err := r.Decode(&n); err != nil {
+		t.Fatalf("Decode(): %v", err)
+	}
+	if n.Qty != nil {
+		t.Errorf("Decode(): null value: want: nil, got: %v", *n.Qty)
+	}
+	var u struct {
+		Count uint `dbf:"COUNT"`
+	}
+	err = r.Decode(&u)
+	if err == nil {
+		t.Fatalf("Decode(): negative value to uint: error required")
+	}
+	if !strings.Contains(err.Error(), "record 2") || !strings.Contains(err.Error(), `"COUNT"`) {
+		t.Errorf("Decode(): error must name record and field: %v", err)
+	}
+}
+
+func Test_Reader_Decode_invalid(t *testing.T) {
+	r, err := NewReader(testProductFile(t))
+	if err != nil {
+		t.Fatalf("NewReader(): %v", err)
+	}
+	r.Read()
+	var p testProduct
+	if err := r.Decode(p); err == nil {
+		t.Errorf("Decode(struct): error required")
+	}
+	var m struct {
+		Name []int `dbf:"NAME"`
+	}
+	if err := r.Decode(&m); err == nil {
+		t.Errorf("Decode(): unsupported type: error required")
+	}
+}
+
+func (c testCode) MarshalText() ([]byte, error) {
+	return []byte(strings.ToUpper(string(c))), nil
+}
+
+func Test_Writer_Encode(t *testing.T) {
+	fields := NewFields()
+	fields.AddCharacterField("NAME", 10)
+	fields.AddNumericField("COUNT", 5, 0)
+	fields.AddNumericField("PRICE", 10, 2)
+	fields.AddLogicalField("FLAG")
+	fields.AddDateField("DATE")
+	fields.AddNumericField("QTY", 5, 0)
+	fields.AddCharacterField("CODE", 5)
+
+	f := &memFile{}
+	w, err := NewWriter(f, fields, 0)
+	if err != nil {
+		t.Fatalf("NewWriter(): %v", err)
+	}
+	qty := uint16(7)
+	products := []testProduct{
+		{
+			testBase: testBase{Name: "Mouse"},
+			Count:    12,
+			Price:    1.25,
+			Flag:     true,
+			Date:     time.Date(2021, 2, 12, 0, 0, 0, 0, time.UTC),
+			Qty:      &qty,
+			Code:     "ab",
+			Missing:  "ignored",
+		},
+		{
+			testBase: testBase{Name: "Keyboard"},
+			Count:    -1,
+		},
+	}
+	for _, p := range products {
+		if err := w.Encode(p); err != nil {
+			t.Fatalf("Encode(): %v", err)
+		}
+	}
+	w.Flush()
+	if w.Err() != nil {
+		t.Fatalf("Writer: %v", w.Err())
+	}
+
+	f.Seek(0, io.SeekStart)
+	r, err := NewReader(f)
+	if err != nil {
+		t.Fatalf("NewReader(): %v", err)
+	}
+	for i, want := range products {
+		if !r.Read() {
+			t.Fatalf("Read(): want: true")
+		}
+		var got testProduct
+		if err := r.Decode(&got); err != nil {
+			t.Fatalf("Decode(): %v", err)
+		}
+		want.Missing = ""
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("record %d:\nwant: %+v\ngot : %+v", i+1, want, got)
+		}
+	}
+	if r.StringFieldValue(6) != "" {
+		t.Errorf("r.StringFieldValue(6): want: %#v, got: %#v", "", r.StringFieldValue(6))
+	}
+}
+
+func Test_Writer_Encode_strict(t *testing.T) {
+	fields := NewFields()
+	fields.AddCharacterField("NAME", 10)
+
+	w, err := NewWriterOptions(&memFile{}, fields, WriterOptions{StrictEncode: true})
+	if err != nil {
+		t.Fatalf("NewWriterOptions(): %v", err)
+	}
+	v := struct {
+		Name  string
+		Count int
+	}{Name: "Abc", Count: 1}
+	if err := w.Encode(&v); err == nil {
+		t.Errorf("Encode(): unknown field in strict mode: error required")
+	}
+	if err := w.Encode(1); err == nil {
+		t.Errorf("Encode(1): error required")
+	}
+
+	// The option of an appender
+	f := &memFile{}
+	w, err = NewWriter(f, fields, 0)
+	if err != nil {
+		t.Fatalf("NewWriter(): %v", err)
+	}
+	w.Flush()
+	a, err := OpenAppender(f)
+	if err != nil {
+		t.Fatalf("OpenAppender(): %v", err)
+	}
+	if err := a.Encode(&v); err != nil {
+		t.Errorf("Encode(): unknown field: %v", err)
+	}
+	a.SetStrictEncode(true)
+	if err := a.Encode(&v); err == nil {
+		t.Errorf("Encode(): unknown field after SetStrictEncode(true): error required")
+	}
+}
+
+func Test_FieldsFromStruct(t *testing.T) {
+	type row struct {
+		testBase
+		Count  int32
+		Amount int64
+		Price  float64   `dbf:"PRICE,N,12,2"`
+		Flag   *bool     `dbf:"FLAG"`
+		Date   time.Time `dbf:"DATE"`
+		Note   []byte    `dbf:"NOTE"`
+		Code   testCode  `dbf:"CODE,C,5"`
+		Rate   float64   `dbf:"RATE,F"`
+		Qty    float64   `dbf:"QTY,N,5"`
+		Total  float64   `dbf:"TOTAL,N,10"`
+		Skip   string    `dbf:"-"`
+	}
+	fields, err := FieldsFromStruct(&row{})
+	if err != nil {
+		t.Fatalf("FieldsFromStruct(): %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		typ    string
+		length int
+		dec    int
+	}{
+		{"NAME", "C", 254, 0},
+		{"COUNT", "N", 11, 0},
+		{"AMOUNT", "N", 19, 0},
+		{"PRICE", "N", 12, 2},
+		{"FLAG", "L", 1, 0},
+		{"DATE", "D", 8, 0},
+		{"NOTE", "M", 10, 0},
+		{"CODE", "C", 5, 0},
+		{"RATE", "F", 20, 0},
+		{"QTY", "N", 5, 0},
+		{"TOTAL", "N", 10, 0},
+	}
+	if fields.Count() != len(tests) {
+		t.Fatalf("fields.Count(): want: %v, got: %v", len(tests), fields.Count())
+	}
+	for i, tc := range tests {
+		name, typ, length, dec := fields.FieldInfo(i)
+		if name != tc.name || typ != tc.typ || length != tc.length || dec != tc.dec {
+			t.Errorf("fields.FieldInfo(%d): want: %v %v %v %v, got: %v %v %v %v", i, tc.name, tc.typ, tc.length, tc.dec, name, typ, length, dec)
+		}
+	}
+}
+
+func Test_FieldsFromStruct_invalid(t *testing.T) {
+	tests := []interface{}{
+		1,
+		struct{ Items []int }{},
+		struct {
+			Name string `dbf:"NAME,C,x"`
+		}{},
+		struct {
+			Name string `dbf:"NAME,XY"`
+		}{},
+		struct {
+			Name string `dbf:"NAME,C,300"`
+		}{},
+		struct{}{},
+	}
+	for _, v := range tests {
+		if _, err := FieldsFromStruct(v); err == nil {
+			t.Errorf("FieldsFromStruct(%#v): error required", v)
+		}
+	}
+}
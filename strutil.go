@@ -63,3 +63,12 @@ func isEmpty(buf []byte) bool {
 	}
 	return true
 }
+
+func isZero(buf []byte) bool {
+	for i := range buf {
+		if buf[i] != 0 {
+			return false
+		}
+	}
+	return true
+}
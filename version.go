@@ -0,0 +1,166 @@
+package dbf
+
+import "fmt"
+
+// Version is the format version of a DBF file.
+type Version int
+
+// Supported versions.
+const (
+	// DBase3 is dBase III file, memo fields are stored in .DBT file.
+	DBase3 Version = iota
+	// FoxPro is FoxPro 2.x file, memo fields are stored in .FPT file.
+	// A file without memo fields is written as dBase III.
+	FoxPro
+	// DBase4 is dBase IV file, memo fields are stored in .DBT file
+	// with length-prefixed blocks.
+	// A file without memo fields is written as dBase III.
+	DBase4
+	// FoxBase is FoxBASE file without memo fields.
+	FoxBase
+	// VisualFoxPro is Visual FoxPro file, memo fields are stored in .FPT file.
+	VisualFoxPro
+	// DBase7 is dBase 7 (Level 7) file, memo fields are stored in .DBT file
+	// with length-prefixed blocks. Field names can be up to 31 characters.
+	// DBase7 is detected when reading a file with id 0x04 by its header layout.
+	DBase7
+)
+
+const (
+	foxBaseId         byte = 0x02
+	dbfId             byte = 0x03
+	dBase4Id          byte = 0x04
+	visualFoxProId    byte = 0x30
+	visualFoxProAIId  byte = 0x31
+	visualFoxProVarId byte = 0x32
+	dbfMemoId         byte = 0x83
+	dBase4MemoId      byte = 0x8B
+	dBase7MemoId      byte = 0x8C
+	foxProMemoId      byte = 0xF5
+)
+
+func (v Version) String() string {
+	switch v {
+	case DBase3:
+		return "dBase III"
+	case FoxPro:
+		return "FoxPro"
+	case DBase4:
+		return "dBase IV"
+	case FoxBase:
+		return "FoxBASE"
+	case VisualFoxPro:
+		return "Visual FoxPro"
+	case DBase7:
+		return "dBase 7"
+	}
+	return fmt.Sprintf("Version(%d)", int(v))
+}
+
+func versionById(id byte) (Version, bool) {
+	switch id {
+	case foxBaseId:
+		return FoxBase, true
+	case dbfId, dbfMemoId:
+		return DBase3, true
+	case dBase4Id, dBase4MemoId:
+		return DBase4, true
+	case foxProMemoId:
+		return FoxPro, true
+	case visualFoxProId, visualFoxProAIId, visualFoxProVarId:
+		return VisualFoxPro, true
+	case dBase7MemoId:
+		return DBase7, true
+	}
+	return 0, false
+}
+
+func (v Version) id(memo bool) byte {
+	switch v {
+	case FoxBase:
+		return foxBaseId
+	case VisualFoxPro:
+		return visualFoxProId
+	case DBase7:
+		if memo {
+			return dBase7MemoId
+		}
+		return dBase4Id
+	}
+	if !memo {
+		return dbfId
+	}
+	switch v {
+	case FoxPro:
+		return foxProMemoId
+	case DBase4:
+		return dBase4MemoId
+	}
+	return dbfMemoId
+}
+
+// backlinkSize returns the size of the database container
+// path stored after the field descriptors.
+func (v Version) backlinkSize() int {
+	if v == VisualFoxPro {
+		return 263
+	}
+	return 0
+}
+
+// headerSize returns the size of the file header.
+func (v Version) headerSize() int {
+	if v == DBase7 {
+		return level7HeaderSize
+	}
+	return headerSize
+}
+
+// fieldSize returns the size of the field descriptor.
+func (v Version) fieldSize() int {
+	if v == DBase7 {
+		return level7FieldSize
+	}
+	return fieldSize
+}
+
+func (v Version) maxNameLen() int {
+	if v == DBase7 {
+		return maxLevel7NameLen
+	}
+	return maxNameLen
+}
+
+func (v Version) checkFieldType(t byte) error {
+	switch t {
+	case 'M':
+		if v == FoxBase {
+			return fmt.Errorf("field type %q not supported by %v", t, v)
+		}
+	case 'G':
+		if v != FoxPro && v != VisualFoxPro && v != DBase7 {
+			return fmt.Errorf("field type %q not supported by %v", t, v)
+		}
+	case 'P':
+		if v != FoxPro && v != VisualFoxPro {
+			return fmt.Errorf("field type %q not supported by %v", t, v)
+		}
+	case 'F':
+		if v == DBase3 || v == FoxBase {
+			return fmt.Errorf("field type %q not supported by %v", t, v)
+		}
+	case 'I', 'B':
+		if v != VisualFoxPro && v != DBase7 {
+			return fmt.Errorf("field type %q not supported by %v", t, v)
+		}
+	case '+', '@', 'O':
+		if v != DBase7 {
+			return fmt.Errorf("field type %q not supported by %v", t, v)
+		}
+	case 'W', 'Y', 'T', 'V', 'Q', '0':
+		if v != VisualFoxPro {
+			return fmt.Errorf("field type %q not supported by %v", t, v)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,79 @@
+package dbf
+
+import "testing"
+
+func Test_versionById(t *testing.T) {
+	tests := []struct {
+		id   byte
+		want Version
+		ok   bool
+	}{
+		{id: 0x03, want: DBase3, ok: true},
+		{id: 0x83, want: DBase3, ok: true},
+		{id: 0xF5, want: FoxPro, ok: true},
+		{id: 0x8B, want: DBase4, ok: true},
+		{id: 0x02, want: FoxBase, ok: true},
+		{id: 0x04, want: DBase4, ok: true},
+		{id: 0x30, want: VisualFoxPro, ok: true},
+		{id: 0x31, want: VisualFoxPro, ok: true},
+		{id: 0x32, want: VisualFoxPro, ok: true},
+		{id: 0x8C, want: DBase7, ok: true},
+		{id: 0x05, want: 0, ok: false},
+	}
+	for _, tc := range tests {
+		got, ok := versionById(tc.id)
+		if got != tc.want || ok != tc.ok {
+			t.Errorf("versionById(%#x): want: %v, %v, got: %v, %v", tc.id, tc.want, tc.ok, got, ok)
+		}
+	}
+}
+
+func Test_Version_id(t *testing.T) {
+	tests := []struct {
+		version Version
+		memo    bool
+		want    byte
+	}{
+		{version: DBase3, memo: false, want: 0x03},
+		{version: DBase3, memo: true, want: 0x83},
+		{version: FoxPro, memo: false, want: 0x03},
+		{version: FoxPro, memo: true, want: 0xF5},
+		{version: DBase4, memo: true, want: 0x8B},
+		{version: FoxBase, memo: false, want: 0x02},
+		{version: VisualFoxPro, memo: false, want: 0x30},
+		{version: VisualFoxPro, memo: true, want: 0x30},
+		{version: DBase7, memo: false, want: 0x04},
+		{version: DBase7, memo: true, want: 0x8C},
+	}
+	for _, tc := range tests {
+		got := tc.version.id(tc.memo)
+		if got != tc.want {
+			t.Errorf("%v.id(%v): want: %#x, got: %#x", tc.version, tc.memo, tc.want, got)
+		}
+	}
+}
+
+func Test_Version_checkFieldType(t *testing.T) {
+	tests := []struct {
+		version Version
+		typ     byte
+		isErr   bool
+	}{
+		{version: DBase3, typ: 'M', isErr: false},
+		{version: FoxBase, typ: 'M', isErr: true},
+		{version: DBase4, typ: 'G', isErr: true},
+		{version: FoxPro, typ: 'P', isErr: false},
+		{version: FoxPro, typ: 'W', isErr: true},
+		{version: VisualFoxPro, typ: 'W', isErr: false},
+		{version: DBase7, typ: 'I', isErr: false},
+		{version: DBase7, typ: '@', isErr: false},
+		{version: DBase7, typ: 'Y', isErr: true},
+		{version: VisualFoxPro, typ: 'O', isErr: true},
+	}
+	for _, tc := range tests {
+		err := tc.version.checkFieldType(tc.typ)
+		if gotErr := (err != nil); gotErr != tc.isErr {
+			t.Errorf("%v.checkFieldType(%q): want error: %v, got error: %v", tc.version, tc.typ, tc.isErr, gotErr)
+		}
+	}
+}
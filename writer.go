@@ -4,6 +4,7 @@ import (
 	"bufio"
 	"fmt"
 	"io"
+	"reflect"
 	"time"
 
 	"golang.org/x/text/encoding"
@@ -23,10 +24,41 @@ type Writer struct {
 	ws       io.WriteSeeker
 	buf      []byte
 	encoder  *encoding.Encoder
+	memo     *memoWriter
+	opts     WriterOptions
 	recCount uint32
 	err      error
 }
 
+// WriterOptions are the options of a Writer.
+type WriterOptions struct {
+	// CodePage is the code page of text fields.
+	// If zero, the text fields will not be encoded.
+	CodePage int
+
+	// Version is the version of the DBF file.
+	Version Version
+
+	// Backlink is the path to the Visual FoxPro database container (.DBC)
+	// which the table belongs to. If empty, the table is a free table.
+	Backlink string
+
+	// LanguageDriver is the language driver name of a dBase 7 file,
+	// e.g. "DBWINUS0".
+	LanguageDriver string
+
+	// MemoBlockSize is the block size of a FoxPro, dBase IV or dBase 7 memo file.
+	// If zero, the block size 64 is used for FoxPro and 512 for dBase IV and dBase 7.
+	// The block size of a dBase IV or dBase 7 memo file must be a multiple of 512.
+	// The block size of a dBase III memo file is always 512.
+	MemoBlockSize int
+
+	// StrictEncode makes Encode return an error for a struct field
+	// which is not found in the fields. By default such struct fields
+	// are ignored. See also Writer.SetStrictEncode.
+	StrictEncode bool
+}
+
 // NewWriter returns a new Writer that writes to ws.
 // The function writes the header of the DBF file.
 // If you call the Flash method afterwards, an empty file will be created.
@@ -48,12 +80,76 @@ type Writer struct {
 //     1253  - Greek Windows
 //
 // If the codePage parameter is zero, the text fields will not be encoded.
-func NewWriter(ws io.WriteSeeker, fields *Fields, codePage int) (w *Writer, err error) {
-	defer func() {
-		if err != nil {
-			err = fmt.Errorf("dbf.NewWriter: %w", err)
-		}
-	}()
+func NewWriter(ws io.WriteSeeker, fields *Fields, codePage int) (*Writer, error) {
+	w, err := newWriter(ws, fields, WriterOptions{CodePage: codePage})
+	if err != nil {
+		return nil, fmt.Errorf("dbf.NewWriter: %w", err)
+	}
+	return w, nil
+}
+
+// NewWriterOptions returns a new Writer that writes to ws
+// with the specified options.
+// The function writes the header of the DBF file.
+// The fields are not modified, the hidden Visual FoxPro _NullFlags field
+// and the version specific field types and lengths are set
+// in a copy of the fields.
+// See NewWriter for the supported code pages.
+func NewWriterOptions(ws io.WriteSeeker, fields *Fields, opts WriterOptions) (*Writer, error) {
+	w, err := newWriter(ws, fields, opts)
+	if err != nil {
+		return nil, fmt.Errorf("dbf.NewWriterOptions: %w", err)
+	}
+	return w, nil
+}
+
+// OpenAppender returns a new Writer that appends records to
+// the existing DBF file rws. The header and the fields are read
+// from the file; the records are written after the last record
+// with the code page of the file.
+// The record count and the modification date are updated by Flush.
+func OpenAppender(rws io.ReadWriteSeeker) (*Writer, error) {
+	if rws == nil {
+		return nil, fmt.Errorf("dbf.OpenAppender: parameter is nil")
+	}
+	w, err := openAppender(rws)
+	if err != nil {
+		return nil, fmt.Errorf("dbf.OpenAppender: %w", err)
+	}
+	return w, nil
+}
+
+func openAppender(rws io.ReadWriteSeeker) (*Writer, error) {
+	if _, err := rws.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+	r, err := newReader(rws)
+	if err != nil {
+		return nil, err
+	}
+	w := &Writer{
+		header:   r.header,
+		fields:   r.fields,
+		ws:       rws,
+		opts:     WriterOptions{CodePage: r.header.codePage(), Version: r.version},
+		recCount: r.header.RecCount,
+	}
+	if w.opts.CodePage != 0 {
+		w.encoder = charmapByPage(w.opts.CodePage).NewEncoder()
+	}
+	w.header.setModDate(time.Now())
+	// Overwrite the end of file marker
+	off := int64(w.header.DataOffset) + int64(w.recCount)*int64(w.header.RecSize)
+	if _, err := rws.Seek(off, io.SeekStart); err != nil {
+		return nil, err
+	}
+	w.writer = bufio.NewWriter(rws)
+	w.buf = make([]byte, int(w.header.RecSize))
+	w.clearBuf()
+	return w, nil
+}
+
+func newWriter(ws io.WriteSeeker, fields *Fields, opts WriterOptions) (w *Writer, err error) {
 	if ws == nil {
 		return nil, fmt.Errorf("parameter is nil")
 	}
@@ -63,41 +159,97 @@ func NewWriter(ws io.WriteSeeker, fields *Fields, codePage int) (w *Writer, err
 	if fields.Count() == 0 {
 		return nil, fmt.Errorf("no fields defined")
 	}
+	// The field types, lengths and the null flags depend on the version,
+	// so the writer modifies its own copy of the fields
+	fields = fields.clone()
+	if err := fields.setLevel7(opts.Version == DBase7); err != nil {
+		return nil, err
+	}
+	for _, item := range fields.items {
+		if err := opts.Version.checkFieldType(item.Type); err != nil {
+			return nil, err
+		}
+		if item.Flags != 0 && opts.Version != VisualFoxPro {
+			return nil, fmt.Errorf("field flags not supported by %v", opts.Version)
+		}
+		if n := opts.Version.maxNameLen(); len(item.name()) > n {
+			return nil, fmt.Errorf("too long field name %q, max len %d", item.name(), n)
+		}
+	}
+	if len(opts.Backlink) > opts.Version.backlinkSize() {
+		return nil, fmt.Errorf("backlink len %d, want len <= %d", len(opts.Backlink), opts.Version.backlinkSize())
+	}
+	if len(opts.LanguageDriver) > 0 && opts.Version != DBase7 {
+		return nil, fmt.Errorf("language driver not supported by %v", opts.Version)
+	}
+	if len(opts.LanguageDriver) >= languageDriverLen {
+		return nil, fmt.Errorf("language driver len %d, want len < %d", len(opts.LanguageDriver), languageDriverLen)
+	}
+	fields.visualFoxPro = opts.Version == VisualFoxPro
+	if opts.Version == VisualFoxPro {
+		fields.setMemoLen(visualFoxProMemoLen)
+		fields.setNullFlags()
+	} else {
+		fields.setMemoLen(memoLen)
+	}
 	w = &Writer{
 		header: newHeader(),
 		fields: fields,
 		ws:     ws,
 		writer: bufio.NewWriter(ws),
+		opts:   opts,
 	}
-	if codePage > 0 {
-		cm := charmapByPage(codePage)
+	if opts.CodePage > 0 {
+		cm := charmapByPage(opts.CodePage)
 		if cm == nil {
-			return nil, fmt.Errorf("unsupported code page %d", codePage)
+			return nil, fmt.Errorf("%w %d", ErrUnsupportedCodePage, opts.CodePage)
 		}
 		w.encoder = cm.NewEncoder()
-		w.header.setCodePage(codePage)
+		w.header.setCodePage(opts.CodePage)
+	}
+	w.header.Id = opts.Version.id(w.fields.hasMemo())
+	if opts.Version == VisualFoxPro && w.fields.hasAutoIncrement() {
+		w.header.Id = visualFoxProAIId
 	}
-	w.header.setFieldCount(w.fields.Count())
+	if opts.Version == VisualFoxPro && w.fields.hasVarLength() {
+		w.header.Id = visualFoxProVarId
+	}
+	if opts.Version == VisualFoxPro && w.fields.hasMemo() {
+		w.header.Flags |= flagMemo
+	}
+	w.header.setFieldCount(w.fields.Count(), opts.Version)
 	w.header.RecSize = uint16(w.fields.recSize)
 
 	if err = w.header.write(w.writer); err != nil {
 		return nil, err
 	}
-	if err = w.fields.write(w.writer); err != nil {
+	if opts.Version == DBase7 {
+		langDriver := make([]byte, level7HeaderSize-headerSize)
+		copy(langDriver, opts.LanguageDriver)
+		if _, err = w.writer.Write(langDriver); err != nil {
+			return nil, err
+		}
+	}
+	if err = w.fields.write(w.writer, opts.Version); err != nil {
 		return nil, err
 	}
 	if err = w.writer.WriteByte(headerEnd); err != nil {
 		return nil, err
 	}
+	if n := opts.Version.backlinkSize(); n > 0 {
+		backlink := make([]byte, n)
+		copy(backlink, opts.Backlink)
+		if _, err = w.writer.Write(backlink); err != nil {
+			return nil, err
+		}
+	}
 	w.buf = make([]byte, int(w.header.RecSize))
 	w.clearBuf()
 	return w, nil
 }
 
 func (w *Writer) clearBuf() {
-	for i := range w.buf {
-		w.buf[i] = ' '
-	}
+	w.fields.clear(w.buf)
 }
 
 // Err returns the first error that was encountered by the Writer.
@@ -108,13 +260,56 @@ func (w *Writer) Err() error {
 	return nil
 }
 
+// SetMemoWriter sets the memo file used to write memo fields.
+// The memo file format (.DBT or .FPT) is defined by the file version.
+// The function writes the header of the memo file.
+func (w *Writer) SetMemoWriter(ws io.WriteSeeker) {
+	if w.err != nil {
+		return
+	}
+	if ws == nil {
+		w.err = fmt.Errorf("SetMemoWriter: parameter is nil")
+		return
+	}
+	memo, err := newMemoWriter(ws, w.opts.Version, w.opts.MemoBlockSize)
+	if err != nil {
+		w.err = fmt.Errorf("SetMemoWriter: %w", err)
+		return
+	}
+	w.memo = memo
+}
+
+// SetMemoAppender sets the existing memo file used to write memo fields.
+// The memo data are written after the last used block of the file.
+// Use it with a Writer returned by OpenAppender.
+func (w *Writer) SetMemoAppender(rws io.ReadWriteSeeker) {
+	if w.err != nil {
+		return
+	}
+	if rws == nil {
+		w.err = fmt.Errorf("SetMemoAppender: parameter is nil")
+		return
+	}
+	memo, err := openMemoWriter(rws, w.opts.Version)
+	if err != nil {
+		w.err = fmt.Errorf("SetMemoAppender: %w", err)
+		return
+	}
+	w.memo = memo
+}
+
 // Write writes a single record to w.
+// The autoincrement fields are assigned their next values.
 func (w *Writer) Write() {
 	if w.err != nil {
 		return
 	}
+	if err := w.fields.setAutoIncrementValues(w.buf); err != nil {
+		w.err = fmt.Errorf("Write: %w", &RecordError{RecNo: w.recCount + 1, Err: err})
+		return
+	}
 	if _, err := w.writer.Write(w.buf); err != nil {
-		w.err = fmt.Errorf("Write: record %d: %w", w.recCount+1, err)
+		w.err = fmt.Errorf("Write: %w", &RecordError{RecNo: w.recCount + 1, Err: err})
 		return
 	}
 	w.recCount++
@@ -145,6 +340,18 @@ func (w *Writer) flush() error {
 	if err := w.header.write(w.ws); err != nil {
 		return err
 	}
+	// modify autoincrement next values in field descriptors
+	if w.fields.hasAutoIncrement() {
+		if _, err := w.ws.Seek(int64(w.opts.Version.headerSize()), io.SeekStart); err != nil {
+			return err
+		}
+		if err := w.fields.write(w.ws, w.opts.Version); err != nil {
+			return err
+		}
+	}
+	if w.memo != nil {
+		return w.memo.flush()
+	}
 	return nil
 }
 
@@ -161,14 +368,31 @@ func (w *Writer) SetDeteted(deleted bool) {
 }
 
 // SetStringFieldValue assigns a value to a field by index.
-// Field type must be Character, Logical, Date or Numeric.
+// Field type must be Character, Logical, Date, Numeric, Float, Double,
+// Integer, Currency, DateTime, Varchar or Memo.
+// A DateTime value must be in the format YYYYMMDDhhmmss.
+// For a memo field the memo file must be set by SetMemoWriter.
 func (w *Writer) SetStringFieldValue(index int, value string) {
 	if w.err != nil {
 		return
 	}
-	err := w.fields.setStringFieldValue(index, w.buf, value, w.encoder)
+	err := w.fields.setStringFieldValue(index, w.buf, value, w.encoder, w.memo)
+	if err != nil {
+		w.err = w.valueError("SetStringFieldValue", index, err)
+	}
+}
+
+// SetBytesFieldValue assigns a value to a field by index.
+// Field type must be Memo, General, Picture, Blob, Varchar or Varbinary.
+// For a memo type field the memo file must be set by SetMemoWriter.
+// The value of a Memo or Varchar field is written without encoding.
+func (w *Writer) SetBytesFieldValue(index int, value []byte) {
+	if w.err != nil {
+		return
+	}
+	err := w.fields.setBytesFieldValue(index, w.buf, value, w.memo)
 	if err != nil {
-		w.err = fmt.Errorf("SetStringFieldValue: %w", err)
+		w.err = w.valueError("SetBytesFieldValue", index, err)
 	}
 }
 
@@ -180,7 +404,7 @@ func (w *Writer) SetBoolFieldValue(index int, value bool) {
 	}
 	err := w.fields.setBoolFieldValue(index, w.buf, value)
 	if err != nil {
-		w.err = fmt.Errorf("SetBoolFieldValue: %w", err)
+		w.err = w.valueError("SetBoolFieldValue", index, err)
 	}
 }
 
@@ -192,30 +416,207 @@ func (w *Writer) SetDateFieldValue(index int, value time.Time) {
 	}
 	err := w.fields.setDateFieldValue(index, w.buf, value)
 	if err != nil {
-		w.err = fmt.Errorf("SetDateFieldValue: %w", err)
+		w.err = w.valueError("SetDateFieldValue", index, err)
 	}
 }
 
 // SetIntFieldValue assigns a value to a field by index.
-// Field type must be Numeric.
+// Field type must be Numeric, Float or Integer.
 func (w *Writer) SetIntFieldValue(index int, value int64) {
 	if w.err != nil {
 		return
 	}
 	err := w.fields.setIntFieldValue(index, w.buf, value)
 	if err != nil {
-		w.err = fmt.Errorf("SetIntFieldValue: %w", err)
+		w.err = w.valueError("SetIntFieldValue", index, err)
 	}
 }
 
 // SetFloatFieldValue assigns a value to a field by index.
-// Field type must be Numeric.
+// Field type must be Numeric, Float, Double or Currency.
+// A Currency value is rounded to 4 decimal places.
 func (w *Writer) SetFloatFieldValue(index int, value float64) {
 	if w.err != nil {
 		return
 	}
 	err := w.fields.setFloatFieldValue(index, w.buf, value)
 	if err != nil {
-		w.err = fmt.Errorf("SetFloatFieldValue: %w", err)
+		w.err = w.valueError("SetFloatFieldValue", index, err)
+	}
+}
+
+// SetCurrencyFieldValue assigns a value to a field by index.
+// The value is an integer scaled by 10000, e.g. 12.5 is passed as 125000.
+// Field type must be Currency.
+func (w *Writer) SetCurrencyFieldValue(index int, value int64) {
+	if w.err != nil {
+		return
+	}
+	err := w.fields.setCurrencyFieldValue(index, w.buf, value)
+	if err != nil {
+		w.err = w.valueError("SetCurrencyFieldValue", index, err)
+	}
+}
+
+// SetDateTimeFieldValue assigns a value to a field by index.
+// The value is truncated to milliseconds.
+// Field type must be DateTime.
+func (w *Writer) SetDateTimeFieldValue(index int, value time.Time) {
+	if w.err != nil {
+		return
+	}
+	err := w.fields.setDateTimeFieldValue(index, w.buf, value)
+	if err != nil {
+		w.err = w.valueError("SetDateTimeFieldValue", index, err)
+	}
+}
+
+// SetNull sets the value of the field by index to null.
+// A Visual FoxPro nullable field gets its null flag set,
+// other Visual FoxPro fields cannot be set to null.
+// Fields of other versions are set blank; binary fields which are not
+// nullable cannot be set to null.
+// Any setter clears the null flag of the field.
+func (w *Writer) SetNull(index int) {
+	if w.err != nil {
+		return
+	}
+	err := w.fields.setNull(index, w.buf)
+	if err != nil {
+		w.err = w.valueError("SetNull", index, err)
+	}
+}
+
+// SetStrictEncode sets whether Encode returns an error for a struct field
+// which is not found in the fields. By default such struct fields are ignored.
+func (w *Writer) SetStrictEncode(strict bool) {
+	if w.err != nil {
+		return
+	}
+	w.opts.StrictEncode = strict
+}
+
+// Encode assigns the values of the struct pointed to by v
+// to the fields and writes the record.
+// The struct fields are mapped as described in Reader.Decode.
+// A nil pointer struct field sets the value to null.
+// The types implementing encoding.TextMarshaler are assigned
+// as strings.
+func (w *Writer) Encode(v interface{}) error {
+	if w.err != nil {
+		return w.Err()
+	}
+	rv := reflect.ValueOf(v)
+	if rv.Kind() == reflect.Ptr && !rv.IsNil() {
+		rv = rv.Elem()
+	}
+	if rv.Kind() != reflect.Struct {
+		return fmt.Errorf("dbf.Writer: Encode: want struct or pointer to struct, got %T", v)
+	}
+	for _, sf := range structFields(rv.Type()) {
+		index, ok := w.fields.FieldIndex(sf.name)
+		if !ok {
+			if w.opts.StrictEncode {
+				w.err = fmt.Errorf("Encode: %w", &RecordError{RecNo: w.recCount + 1, Err: fmt.Errorf("field %q not found", sf.name)})
+				return w.Err()
+			}
+			continue
+		}
+		if err := w.encodeValue(index, rv.FieldByIndex(sf.index)); err != nil {
+			w.err = w.valueError("Encode", index, err)
+			return w.Err()
+		}
+	}
+	w.Write()
+	return w.Err()
+}
+
+// valueError returns err with the record and field context.
+func (w *Writer) valueError(fn string, index int, err error) error {
+	return fmt.Errorf("%s: %w", fn, &RecordError{RecNo: w.recCount + 1, Err: w.fields.fieldError(index, err)})
+}
+
+// Field value by name
+
+func (w *Writer) fieldIndex(fn, name string) (int, bool) {
+	if w.err != nil {
+		return 0, false
+	}
+	index, ok := w.fields.FieldIndex(name)
+	if !ok {
+		w.err = fmt.Errorf("%s: field %q not found", fn, name)
+	}
+	return index, ok
+}
+
+// SetStringFieldValueByName assigns a value to a field by name.
+// See SetStringFieldValue.
+func (w *Writer) SetStringFieldValueByName(name string, value string) {
+	if index, ok := w.fieldIndex("SetStringFieldValueByName", name); ok {
+		w.SetStringFieldValue(index, value)
+	}
+}
+
+// SetBoolFieldValueByName assigns a value to a field by name.
+// See SetBoolFieldValue.
+func (w *Writer) SetBoolFieldValueByName(name string, value bool) {
+	if index, ok := w.fieldIndex("SetBoolFieldValueByName", name); ok {
+		w.SetBoolFieldValue(index, value)
+	}
+}
+
+// SetDateFieldValueByName assigns a value to a field by name.
+// See SetDateFieldValue.
+func (w *Writer) SetDateFieldValueByName(name string, value time.Time) {
+	if index, ok := w.fieldIndex("SetDateFieldValueByName", name); ok {
+		w.SetDateFieldValue(index, value)
+	}
+}
+
+// SetIntFieldValueByName assigns a value to a field by name.
+// See SetIntFieldValue.
+func (w *Writer) SetIntFieldValueByName(name string, value int64) {
+	if index, ok := w.fieldIndex("SetIntFieldValueByName", name); ok {
+		w.SetIntFieldValue(index, value)
+	}
+}
+
+// SetFloatFieldValueByName assigns a value to a field by name.
+// See SetFloatFieldValue.
+func (w *Writer) SetFloatFieldValueByName(name string, value float64) {
+	if index, ok := w.fieldIndex("SetFloatFieldValueByName", name); ok {
+		w.SetFloatFieldValue(index, value)
+	}
+}
+
+// SetNullByName sets the value of the field by name to null.
+// See SetNull.
+func (w *Writer) SetNullByName(name string) {
+	if index, ok := w.fieldIndex("SetNullByName", name); ok {
+		w.SetNull(index)
+	}
+}
+
+// SetBytesFieldValueByName assigns a value to a field by name.
+// See SetBytesFieldValue.
+func (w *Writer) SetBytesFieldValueByName(name string, value []byte) {
+	if index, ok := w.fieldIndex("SetBytesFieldValueByName", name); ok {
+		w.SetBytesFieldValue(index, value)
+	}
+}
+
+// SetCurrencyFieldValueByName assigns a value to a field by name.
+// See SetCurrencyFieldValue.
+func (w *Writer) SetCurrencyFieldValueByName(name string, value int64) {
+	if index, ok := w.fieldIndex("SetCurrencyFieldValueByName", name); ok {
+		w.SetCurrencyFieldValue(index, value)
+	}
+}
+
+// SetDateTimeFieldValueByName assigns a value to a field by name.
+// See SetDateTimeFieldValue.
+func (w *Writer) SetDateTimeFieldValueByName(name string, value time.Time) {
+	if index, ok := w.fieldIndex("SetDateTimeFieldValueByName", name); ok {
+		w.SetDateTimeFieldValue(index, value)
 	}
 }
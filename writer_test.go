@@ -1,6 +1,7 @@
 package dbf
 
 import (
+	"bytes"
 	"io"
 	"os"
 	"reflect"
@@ -95,3 +96,584 @@ func Test_Writer_write_records(t *testing.T) {
 		t.Errorf("dbf file bytes:\nwant: %#v\ngot : %#v", want, got)
 	}
 }
+
+func Test_Writer_Reader_version(t *testing.T) {
+	for _, version := range []Version{DBase3, DBase4, FoxBase, FoxPro, VisualFoxPro, DBase7} {
+		fields := NewFields()
+		fields.AddCharacterField("NAME", 10)
+		fields.AddNumericField("COUNT", 5, 0)
+
+		f := &memFile{}
+		w, err := NewWriterOptions(f, fields, WriterOptions{Version: version})
+		if err != nil {
+			t.Fatalf("NewWriterOptions(%v): %v", version, err)
+		}
+		w.SetStringFieldValue(0, "Abc")
+		w.SetIntFieldValue(1, 12)
+		w.Write()
+		w.Flush()
+		if w.Err() != nil {
+			t.Fatalf("Writer(%v): %v", version, w.Err())
+		}
+
+		f.Seek(0, io.SeekStart)
+		r, err := NewReader(f)
+		if err != nil {
+			t.Fatalf("NewReader(%v): %v", version, err)
+		}
+		if version == FoxPro || version == DBase4 {
+			// Files without memo fields are written as dBase III
+			version = DBase3
+		}
+		if r.Version() != version {
+			t.Errorf("r.Version(): want: %v, got: %v", version, r.Version())
+		}
+		if r.Fields().Count() != 2 {
+			t.Errorf("r.Fields().Count(): want: %v, got: %v", 2, r.Fields().Count())
+		}
+		if !r.Read() {
+			t.Fatalf("Read(%v): want: true", version)
+		}
+		if r.StringFieldValue(0) != "Abc" || r.IntFieldValue(1) != 12 {
+			t.Errorf("Read(%v): want: %v %v, got: %v %v", version, "Abc", 12, r.StringFieldValue(0), r.IntFieldValue(1))
+		}
+	}
+}
+
+func Test_Writer_Reader_VisualFoxPro(t *testing.T) {
+	fields := NewFields()
+	fields.AddCharacterField("NAME", 10)
+	fields.AddCharacterField("CODE", 4)
+	fields.AddMemoField("NOTE")
+	fields.AddBlobField("DATA")
+	fields.SetFieldFlags(1, FlagBinary)
+
+	dbf := &memFile{}
+	fpt := &memFile{}
+
+	opts := WriterOptions{CodePage: 1251, Version: VisualFoxPro, Backlink: "..\\data\\sales.dbc"}
+	w, err := NewWriterOptions(dbf, fields, opts)
+	if err != nil {
+		t.Fatalf("NewWriterOptions(): %v", err)
+	}
+	w.SetMemoWriter(fpt)
+	w.SetStringFieldValue(0, "Мышь")
+	w.SetStringFieldValue(1, "\xE0\xE1")
+	w.SetStringFieldValue(2, "Заметка")
+	w.Write()
+	w.Flush()
+	if w.Err() != nil {
+		t.Fatalf("Writer: %v", w.Err())
+	}
+	if dbf.buf[0] != visualFoxProId {
+		t.Errorf("header Id: want: %#x, got: %#x", visualFoxProId, dbf.buf[0])
+	}
+	// The database container flag is set only in a .DBC file
+	if dbf.buf[28] != flagMemo {
+		t.Errorf("header Flags: want: %#x, got: %#x", flagMemo, dbf.buf[28])
+	}
+
+	dbf.Seek(0, io.SeekStart)
+	r, err := NewReader(dbf)
+	if err != nil {
+		t.Fatalf("NewReader(): %v", err)
+	}
+	r.SetMemoReader(fpt)
+
+	if r.Backlink() != opts.Backlink {
+		t.Errorf("r.Backlink(): want: %#v, got: %#v", opts.Backlink, r.Backlink())
+	}
+	if flags := r.Fields().FieldFlags(1); flags != FlagBinary {
+		t.Errorf("r.Fields().FieldFlags(1): want: %#x, got: %#x", FlagBinary, flags)
+	}
+	if _, _, length, _ := r.Fields().FieldInfo(2); length != 4 {
+		t.Errorf("r.Fields().FieldInfo(2): length: want: %v, got: %v", 4, length)
+	}
+	if !r.Read() {
+		t.Fatalf("Read(): want: true")
+	}
+	if got := r.StringFieldValue(0); got != "Мышь" {
+		t.Errorf("r.StringFieldValue(0): want: %#v, got: %#v", "Мышь", got)
+	}
+	if got := r.StringFieldValue(1); got != "\xE0\xE1" {
+		t.Errorf("r.StringFieldValue(1): want: %#v, got: %#v", "\xE0\xE1", got)
+	}
+	if got := r.StringFieldValue(2); got != "Заметка" {
+		t.Errorf("r.StringFieldValue(2): want: %#v, got: %#v", "Заметка", got)
+	}
+	if got := r.BytesFieldValue(3); got != nil {
+		t.Errorf("r.BytesFieldValue(3): want: %#v, got: %#v", nil, got)
+	}
+	if r.Err() != nil {
+		t.Errorf("Reader: %v", r.Err())
+	}
+}
+
+func Test_NewWriterOptions_field_flags_dBase3(t *testing.T) {
+	fields := NewFields()
+	fields.AddCharacterField("NAME", 10)
+	fields.SetFieldFlags(0, FlagBinary)
+
+	_, err := NewWriterOptions(&memFile{}, fields, WriterOptions{Version: DBase3})
+	if err == nil {
+		t.Errorf("NewWriterOptions(): field flags in dBase III: error required")
+	}
+}
+
+func Test_Writer_Reader_autoIncrement(t *testing.T) {
+	fields := NewFields()
+	fields.AddAutoIncrementField("ID", 100, 2)
+	fields.AddCharacterField("NAME", 10)
+
+	dbf := &memFile{}
+	w, err := NewWriterOptions(dbf, fields, WriterOptions{Version: VisualFoxPro})
+	if err != nil {
+		t.Fatalf("NewWriterOptions(): %v", err)
+	}
+	for _, name := range []string{"one", "two", "three"} {
+		w.SetStringFieldValue(1, name)
+		w.Write()
+	}
+	w.Flush()
+	if w.Err() != nil {
+		t.Fatalf("Writer: %v", w.Err())
+	}
+	if dbf.buf[0] != visualFoxProAIId {
+		t.Errorf("header Id: want: %#x, got: %#x", visualFoxProAIId, dbf.buf[0])
+	}
+
+	dbf.Seek(0, io.SeekStart)
+	r, err := NewReader(dbf)
+	if err != nil {
+		t.Fatalf("NewReader(): %v", err)
+	}
+	if flags := r.Fields().FieldFlags(0); flags != FlagAutoIncrement {
+		t.Errorf("r.Fields().FieldFlags(0): want: %#x, got: %#x", FlagAutoIncrement, flags)
+	}
+	if item := r.Fields().items[0]; item.AutoIncNext != 106 || item.AutoIncStep != 2 {
+		t.Errorf("autoincrement next, step: want: %v %v, got: %v %v", 106, 2, item.AutoIncNext, item.AutoIncStep)
+	}
+	want := int64(100)
+	for r.Read() {
+		if got := r.IntFieldValue(0); got != want {
+			t.Errorf("r.IntFieldValue(0): want: %v, got: %v", want, got)
+		}
+		want += 2
+	}
+	if r.Err() != nil {
+		t.Errorf("Reader: %v", r.Err())
+	}
+	if want != 106 {
+		t.Errorf("records read: want: %v, got: %v", 3, (want-100)/2)
+	}
+}
+
+func Test_Writer_Reader_DBase7(t *testing.T) {
+	fields := NewFields()
+	fields.AddAutoIncrementField("ID", 1, 1)
+	fields.AddCharacterField("CUSTOMER_NAME", 20)
+	fields.AddIntegerField("QUANTITY")
+	fields.AddDoubleField("UNIT_PRICE", 2)
+	fields.AddDateTimeField("CREATED_AT")
+	fields.AddMemoField("DESCRIPTION")
+
+	dbf := &memFile{}
+	dbt := &memFile{}
+	opts := WriterOptions{Version: DBase7, LanguageDriver: "DBWINUS0"}
+	w, err := NewWriterOptions(dbf, fields, opts)
+	if err != nil {
+		t.Fatalf("NewWriterOptions(): %v", err)
+	}
+	w.SetMemoWriter(dbt)
+	created := time.Date(2021, 3, 4, 15, 16, 17, 0, time.UTC)
+	w.SetStringFieldValue(1, "Smith")
+	w.SetIntFieldValue(2, -5)
+	w.SetFloatFieldValue(3, 12.5)
+	w.SetDateTimeFieldValue(4, created)
+	w.SetStringFieldValue(5, "Note")
+	w.Write()
+	w.SetStringFieldValue(1, "Jones")
+	w.SetNull(2)
+	w.SetNull(4)
+	w.Write()
+	w.Flush()
+	if w.Err() != nil {
+		t.Fatalf("Writer: %v", w.Err())
+	}
+	if dbf.buf[0] != dBase7MemoId {
+		t.Errorf("header Id: want: %#x, got: %#x", dBase7MemoId, dbf.buf[0])
+	}
+
+	dbf.Seek(0, io.SeekStart)
+	r, err := NewReader(dbf)
+	if err != nil {
+		t.Fatalf("NewReader(): %v", err)
+	}
+	r.SetMemoReader(dbt)
+	if r.Version() != DBase7 {
+		t.Errorf("r.Version(): want: %v, got: %v", DBase7, r.Version())
+	}
+	if r.LanguageDriver() != opts.LanguageDriver {
+		t.Errorf("r.LanguageDriver(): want: %#v, got: %#v", opts.LanguageDriver, r.LanguageDriver())
+	}
+	wantTypes := "+CIO@M"
+	for i := range wantTypes {
+		name, typ, _, _ := r.Fields().FieldInfo(i)
+		if typ != wantTypes[i:i+1] {
+			t.Errorf("r.Fields().FieldInfo(%d): type: want: %v, got: %v", i, wantTypes[i:i+1], typ)
+		}
+		if i == 1 && name != "CUSTOMER_NAME" {
+			t.Errorf("r.Fields().FieldInfo(1): name: want: %v, got: %v", "CUSTOMER_NAME", name)
+		}
+	}
+	if next := r.Fields().items[0].AutoIncNext; next != 3 {
+		t.Errorf("autoincrement next: want: %v, got: %v", 3, next)
+	}
+	if !r.Read() {
+		t.Fatalf("Read(): want: true")
+	}
+	if got := r.IntFieldValue(0); got != 1 {
+		t.Errorf("r.IntFieldValue(0): want: %v, got: %v", 1, got)
+	}
+	if got := r.StringFieldValue(1); got != "Smith" {
+		t.Errorf("r.StringFieldValue(1): want: %v, got: %v", "Smith", got)
+	}
+	if got := r.IntFieldValue(2); got != -5 {
+		t.Errorf("r.IntFieldValue(2): want: %v, got: %v", -5, got)
+	}
+	if got := r.FloatFieldValue(3); got != 12.5 {
+		t.Errorf("r.FloatFieldValue(3): want: %v, got: %v", 12.5, got)
+	}
+	if got := r.DateTimeFieldValue(4); !got.Equal(created) {
+		t.Errorf("r.DateTimeFieldValue(4): want: %v, got: %v", created, got)
+	}
+	if got := r.StringFieldValue(5); got != "Note" {
+		t.Errorf("r.StringFieldValue(5): want: %v, got: %v", "Note", got)
+	}
+	if !r.Read() {
+		t.Fatalf("Read(): want: true")
+	}
+	if got := r.IntFieldValue(0); got != 2 {
+		t.Errorf("r.IntFieldValue(0): want: %v, got: %v", 2, got)
+	}
+	if !r.IsNull(2) || !r.IsNull(4) {
+		t.Errorf("r.IsNull(): blank binary fields: want: true")
+	}
+	if r.Err() != nil {
+		t.Errorf("Reader: %v", r.Err())
+	}
+}
+
+func Test_NewWriterOptions_long_name(t *testing.T) {
+	fields := NewFields()
+	fields.AddCharacterField("CUSTOMER_NAME", 20)
+
+	if _, err := NewWriterOptions(&memFile{}, fields, WriterOptions{Version: DBase3}); err == nil {
+		t.Errorf("NewWriterOptions(): long field name in dBase III: error required")
+	}
+	if _, err := NewWriterOptions(&memFile{}, fields, WriterOptions{Version: DBase7}); err != nil {
+		t.Errorf("NewWriterOptions(): long field name in dBase 7: %v", err)
+	}
+}
+
+func Test_Writer_Reader_by_name(t *testing.T) {
+	fields := NewFields()
+	fields.AddCharacterField("NAME", 10)
+	fields.AddLogicalField("FLAG")
+	fields.AddDateField("DATE")
+	fields.AddNumericField("COUNT", 5, 0)
+	fields.AddNumericField("PRICE", 10, 2)
+
+	f := &memFile{}
+	w, err := NewWriter(f, fields, 0)
+	if err != nil {
+		t.Fatalf("NewWriter(): %v", err)
+	}
+	date := time.Date(2021, 2, 12, 0, 0, 0, 0, time.UTC)
+	w.SetStringFieldValueByName("name", "Abc")
+	w.SetBoolFieldValueByName("Flag", true)
+	w.SetDateFieldValueByName("date", date)
+	w.SetIntFieldValueByName("count", 12)
+	w.SetFloatFieldValueByName("price", 1.5)
+	w.Write()
+	w.Flush()
+	if w.Err() != nil {
+		t.Fatalf("Writer: %v", w.Err())
+	}
+	w.SetIntFieldValueByName("unknown", 1)
+	if w.Err() == nil {
+		t.Errorf("SetIntFieldValueByName('unknown'): error required")
+	}
+
+	f.Seek(0, io.SeekStart)
+	r, err := NewReader(f)
+	if err != nil {
+		t.Fatalf("NewReader(): %v", err)
+	}
+	if !r.Read() {
+		t.Fatalf("Read(): want: true")
+	}
+	if got := r.StringFieldValueByName("name"); got != "Abc" {
+		t.Errorf("r.StringFieldValueByName('name'): want: %v, got: %v", "Abc", got)
+	}
+	if got := r.BoolFieldValueByName("flag"); !got {
+		t.Errorf("r.BoolFieldValueByName('flag'): want: %v, got: %v", true, got)
+	}
+	if got := r.DateFieldValueByName("date"); !got.Equal(date) {
+		t.Errorf("r.DateFieldValueByName('date'): want: %v, got: %v", date, got)
+	}
+	if got := r.IntFieldValueByName("count"); got != 12 {
+		t.Errorf("r.IntFieldValueByName('count'): want: %v, got: %v", 12, got)
+	}
+	if got := r.FloatFieldValueByName("price"); got != 1.5 {
+		t.Errorf("r.FloatFieldValueByName('price'): want: %v, got: %v", 1.5, got)
+	}
+	if r.IsNullByName("name") {
+		t.Errorf("r.IsNullByName('name'): want: false")
+	}
+	if r.Err() != nil {
+		t.Errorf("Reader: %v", r.Err())
+	}
+	r.StringFieldValueByName("unknown")
+	if r.Err() == nil {
+		t.Errorf("r.StringFieldValueByName('unknown'): error required")
+	}
+}
+
+func Test_OpenAppender(t *testing.T) {
+	for _, version := range []Version{DBase3, FoxPro, VisualFoxPro} {
+		fields := NewFields()
+		fields.AddCharacterField("NAME", 10)
+		fields.AddMemoField("NOTE")
+
+		dbf := &memFile{}
+		memo := &memFile{}
+		w, err := NewWriterOptions(dbf, fields, WriterOptions{CodePage: 866, Version: version})
+		if err != nil {
+			t.Fatalf("NewWriterOptions(%v): %v", version, err)
+		}
+		w.SetMemoWriter(memo)
+		w.SetStringFieldValue(0, "Мышь")
+		w.SetStringFieldValue(1, "First")
+		w.Write()
+		w.Flush()
+		if w.Err() != nil {
+			t.Fatalf("Writer(%v): %v", version, w.Err())
+		}
+
+		a, err := OpenAppender(dbf)
+		if err != nil {
+			t.Fatalf("OpenAppender(%v): %v", version, err)
+		}
+		a.SetMemoAppender(memo)
+		a.SetStringFieldValue(0, "Кот")
+		a.SetStringFieldValue(1, "Second")
+		a.Write()
+		a.Flush()
+		if a.Err() != nil {
+			t.Fatalf("Appender(%v): %v", version, a.Err())
+		}
+
+		dbf.Seek(0, io.SeekStart)
+		r, err := NewReader(dbf)
+		if err != nil {
+			t.Fatalf("NewReader(%v): %v", version, err)
+		}
+		r.SetMemoReader(memo)
+		if r.RecordCount() != 2 {
+			t.Errorf("r.RecordCount(%v): want: %v, got: %v", version, 2, r.RecordCount())
+		}
+		want := [][]string{{"Мышь", "First"}, {"Кот", "Second"}}
+		i := 0
+		for r.Read() {
+			if i >= len(want) {
+				t.Fatalf("Read(%v): too many records", version)
+			}
+			if name, note := r.StringFieldValue(0), r.StringFieldValue(1); name != want[i][0] || note != want[i][1] {
+				t.Errorf("record %d (%v): want: %v %v, got: %v %v", i+1, version, want[i][0], want[i][1], name, note)
+			}
+			i++
+		}
+		if r.Err() != nil {
+			t.Errorf("Reader(%v): %v", version, r.Err())
+		}
+		if i != 2 {
+			t.Errorf("Read(%v): records: want: %v, got: %v", version, 2, i)
+		}
+		if dbf.buf[len(dbf.buf)-1] != fileEnd {
+			t.Errorf("end of file marker (%v): want: %#x, got: %#x", version, fileEnd, dbf.buf[len(dbf.buf)-1])
+		}
+	}
+}
+
+func Test_OpenAppender_autoIncrement(t *testing.T) {
+	fields := NewFields()
+	fields.AddAutoIncrementField("ID", 1, 1)
+
+	dbf := &memFile{}
+	w, err := NewWriterOptions(dbf, fields, WriterOptions{Version: VisualFoxPro})
+	if err != nil {
+		t.Fatalf("NewWriterOptions(): %v", err)
+	}
+	w.Write()
+	w.Flush()
+
+	a, err := OpenAppender(dbf)
+	if err != nil {
+		t.Fatalf("OpenAppender(): %v", err)
+	}
+	a.Write()
+	a.Flush()
+	if a.Err() != nil {
+		t.Fatalf("Appender: %v", a.Err())
+	}
+
+	dbf.Seek(0, io.SeekStart)
+	r, err := NewReader(dbf)
+	if err != nil {
+		t.Fatalf("NewReader(): %v", err)
+	}
+	for want := int64(1); want <= 2; want++ {
+		if !r.Read() {
+			t.Fatalf("Read(): want: true")
+		}
+		if got := r.IntFieldValue(0); got != want {
+			t.Errorf("r.IntFieldValue(0): want: %v, got: %v", want, got)
+		}
+	}
+}
+
+func fieldInfos(f *Fields) [][4]interface{} {
+	var infos [][4]interface{}
+	for i := 0; i < f.Count(); i++ {
+		name, typ, length, dec := f.FieldInfo(i)
+		infos = append(infos, [4]interface{}{name, typ, length, dec})
+	}
+	return infos
+}
+
+func Test_NewWriterOptions_DBase7_fields_unchanged(t *testing.T) {
+	fields := NewFields()
+	fields.AddAutoIncrementField("ID", 1, 1)
+	fields.AddDateTimeField("TIME")
+	fields.AddDoubleField("AMOUNT", 2)
+	fields.AddBlobField("DATA")
+	want := fieldInfos(fields)
+
+	if _, err := NewWriterOptions(&memFile{}, fields, WriterOptions{Version: DBase7}); err != nil {
+		t.Fatalf("NewWriterOptions(DBase7): %v", err)
+	}
+	if got := fieldInfos(fields); !reflect.DeepEqual(got, want) {
+		t.Errorf("fields after dBase 7 writer: want: %v, got: %v", want, got)
+	}
+	if _, err := NewWriterOptions(&memFile{}, fields, WriterOptions{Version: VisualFoxPro}); err != nil {
+		t.Errorf("NewWriterOptions(VisualFoxPro) after dBase 7 writer: %v", err)
+	}
+
+	// Failed conversion
+	fields = NewFields()
+	fields.AddDateTimeField("TIME")
+	fields.AddAutoIncrementField("ID", 1, 2)
+	want = fieldInfos(fields)
+	if _, err := NewWriterOptions(&memFile{}, fields, WriterOptions{Version: DBase7}); err == nil {
+		t.Fatalf("NewWriterOptions(DBase7): autoincrement step 2: error required")
+	}
+	if got := fieldInfos(fields); !reflect.DeepEqual(got, want) {
+		t.Errorf("fields after failed dBase 7 writer: want: %v, got: %v", want, got)
+	}
+}
+
+func Test_NewWriterOptions_VisualFoxPro_fields_unchanged(t *testing.T) {
+	fields := NewFields()
+	fields.AddCharacterField("NAME", 10)
+	fields.SetFieldFlags(0, FlagNullable)
+	fields.AddVarcharField("CODE", 5)
+	fields.AddMemoField("NOTE")
+	want := fieldInfos(fields)
+
+	if _, err := NewWriterOptions(&memFile{}, fields, WriterOptions{Version: VisualFoxPro}); err != nil {
+		t.Fatalf("NewWriterOptions(): %v", err)
+	}
+	if got := fieldInfos(fields); !reflect.DeepEqual(got, want) {
+		t.Errorf("fields after Visual FoxPro writer: want: %v, got: %v", want, got)
+	}
+	if _, ok := fields.FieldIndex(nullFlagsName); ok {
+		t.Errorf("fields.FieldIndex(%q): want: false", nullFlagsName)
+	}
+}
+
+func Test_Writer_Reader_by_name_VisualFoxPro(t *testing.T) {
+	fields := NewFields()
+	fields.AddCurrencyField("PRICE")
+	fields.AddDateTimeField("TIME")
+	fields.AddVarbinaryField("DATA", 5)
+	fields.AddCharacterField("NAME", 10)
+	fields.SetFieldFlags(3, FlagNullable)
+	fields.AddLogicalField("FLAG")
+	fields.AddDateField("DATE")
+	fields.AddNumericField("COUNT", 5, 0)
+	fields.AddNumericField("RATE", 5, 2)
+
+	f := &memFile{}
+	w, err := NewWriterOptions(f, fields, WriterOptions{Version: VisualFoxPro})
+	if err != nil {
+		t.Fatalf("NewWriterOptions(): %v", err)
+	}
+	tm := time.Date(2021, 2, 12, 13, 45, 30, 0, time.UTC)
+	date := time.Date(2021, 2, 12, 0, 0, 0, 0, time.UTC)
+	w.SetCurrencyFieldValueByName("price", 125000)
+	w.SetDateTimeFieldValueByName("time", tm)
+	w.SetBytesFieldValueByName("data", []byte{1, 2})
+	w.SetNullByName("name")
+	w.SetBoolFieldValueByName("flag", true)
+	w.SetDateFieldValueByName("date", date)
+	w.SetIntFieldValueByName("count", 7)
+	w.SetFloatFieldValueByName("rate", 1.25)
+	w.Write()
+	w.Flush()
+	if w.Err() != nil {
+		t.Fatalf("Writer: %v", w.Err())
+	}
+
+	f.Seek(0, io.SeekStart)
+	r, err := NewReader(f)
+	if err != nil {
+		t.Fatalf("NewReader(): %v", err)
+	}
+	if !r.Read() {
+		t.Fatalf("Read(): want: true")
+	}
+	if got := r.CurrencyFieldValueByName("price"); got != 125000 {
+		t.Errorf("r.CurrencyFieldValueByName('price'): want: %v, got: %v", 125000, got)
+	}
+	if got := r.DateTimeFieldValueByName("time"); !got.Equal(tm) {
+		t.Errorf("r.DateTimeFieldValueByName('time'): want: %v, got: %v", tm, got)
+	}
+	if got := r.BytesFieldValueByName("data"); !bytes.Equal(got, []byte{1, 2}) {
+		t.Errorf("r.BytesFieldValueByName('data'): want: %v, got: %v", []byte{1, 2}, got)
+	}
+	if got := r.NullStringFieldValueByName("name"); got.Valid {
+		t.Errorf("r.NullStringFieldValueByName('name'): want: null, got: %v", got)
+	}
+	if got := r.NullBoolFieldValueByName("flag"); !got.Valid || !got.Bool {
+		t.Errorf("r.NullBoolFieldValueByName('flag'): want: true, got: %v", got)
+	}
+	if got := r.NullDateFieldValueByName("date"); !got.Valid || !got.Time.Equal(date) {
+		t.Errorf("r.NullDateFieldValueByName('date'): want: %v, got: %v", date, got)
+	}
+	if got := r.NullDateTimeFieldValueByName("time"); !got.Valid || !got.Time.Equal(tm) {
+		t.Errorf("r.NullDateTimeFieldValueByName('time'): want: %v, got: %v", tm, got)
+	}
+	if got := r.NullIntFieldValueByName("count"); !got.Valid || got.Int64 != 7 {
+		t.Errorf("r.NullIntFieldValueByName('count'): want: %v, got: %v", 7, got)
+	}
+	if got := r.NullFloatFieldValueByName("rate"); !got.Valid || got.Float64 != 1.25 {
+		t.Errorf("r.NullFloatFieldValueByName('rate'): want: %v, got: %v", 1.25, got)
+	}
+	if r.Err() != nil {
+		t.Errorf("Reader: %v", r.Err())
+	}
+	if got := r.NullIntFieldValueByName("unknown"); got.Valid || r.Err() == nil {
+		t.Errorf("r.NullIntFieldValueByName('unknown'): error required")
+	}
+}